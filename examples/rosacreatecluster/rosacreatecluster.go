@@ -25,21 +25,20 @@ func main() {
 		logger = ginkgo.GinkgoLogr
 	)
 
-	provider, err := rosa.New(
+	provider, err := rosa.NewWithOptions(
 		ctx,
-		os.Getenv("OCM_TOKEN"),
-		os.Getenv("OCM_CLIENT_ID"),
-		os.Getenv("OCM_CLIENT_SECRET"),
-		ocmclient.Stage,
 		logger,
-		&awscloud.AWSCredentials{Profile: "", Region: ""},
+		rosa.WithToken(os.Getenv("OCM_TOKEN")),
+		rosa.WithClientCredentials(os.Getenv("OCM_CLIENT_ID"), os.Getenv("OCM_CLIENT_SECRET")),
+		rosa.WithOCMEnvironment(ocmclient.Stage),
+		rosa.WithAWSCredentials(&awscloud.AWSCredentials{Profile: "", Region: ""}),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create rosa provider: %v", err)
 	}
 
 	defer func() {
-		_ = provider.Client.Close()
+		_ = provider.Close(ctx)
 	}()
 
 	clusterID, err := provider.CreateCluster(
@@ -37,7 +37,7 @@ func main() {
 	}
 
 	defer func() {
-		_ = provider.Client.Close()
+		_ = provider.Close(ctx)
 	}()
 
 	deleteOptions := &rosa.DeleteClusterOptions{
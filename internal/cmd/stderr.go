@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// warningPrefixes are the line prefixes cli tools use for non fatal messages
+var warningPrefixes = []string{"W:", "WARN:", "WARN ", "WARNING:"}
+
+// Stderr represents command stderr output split into warning and error lines
+type Stderr struct {
+	Warnings []string
+	Errors   []string
+}
+
+// String returns the error lines joined back together
+func (s Stderr) String() string {
+	return strings.Join(s.Errors, "\n")
+}
+
+// ParseStderr splits the stderr output of a command into warning and error lines,
+// blank lines are dropped
+func ParseStderr(data io.Writer) Stderr {
+	var stderr Stderr
+
+	for _, line := range strings.Split(fmt.Sprint(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if isWarning(line) {
+			stderr.Warnings = append(stderr.Warnings, line)
+			continue
+		}
+
+		stderr.Errors = append(stderr.Errors, line)
+	}
+
+	return stderr
+}
+
+// isWarning returns true when the line is a warning message
+func isWarning(line string) bool {
+	upper := strings.ToUpper(line)
+	for _, prefix := range warningPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stderr", func() {
+	It("should separate warnings from errors", func() {
+		stderr := bytes.NewBufferString(`W: Region flag will be removed from this command in future versions
+WARN: You are choosing to use AWS PrivateLink for your cluster
+E: Failed to create cluster: cluster name must be unique
+
+WARNING: the cluster version is deprecated
+ERR: failed to find role
+`)
+
+		parsed := ParseStderr(stderr)
+		Expect(parsed.Warnings).Should(Equal([]string{
+			"W: Region flag will be removed from this command in future versions",
+			"WARN: You are choosing to use AWS PrivateLink for your cluster",
+			"WARNING: the cluster version is deprecated",
+		}))
+		Expect(parsed.Errors).Should(Equal([]string{
+			"E: Failed to create cluster: cluster name must be unique",
+			"ERR: failed to find role",
+		}))
+		Expect(parsed.String()).Should(Equal("E: Failed to create cluster: cluster name must be unique\nERR: failed to find role"))
+	})
+
+	It("should handle output with only warnings", func() {
+		parsed := ParseStderr(bytes.NewBufferString("W: something minor\n"))
+		Expect(parsed.Warnings).Should(HaveLen(1))
+		Expect(parsed.Errors).Should(BeEmpty())
+		Expect(parsed.String()).Should(BeEmpty())
+	})
+
+	It("should handle empty output", func() {
+		parsed := ParseStderr(&bytes.Buffer{})
+		Expect(parsed.Warnings).Should(BeEmpty())
+		Expect(parsed.Errors).Should(BeEmpty())
+	})
+})
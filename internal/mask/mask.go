@@ -0,0 +1,29 @@
+package mask
+
+import "net/url"
+
+// Masked replaces the credential when set
+const Masked = "xxxxx"
+
+// Credential masks the credential, unset credentials are kept empty
+func Credential(credential string) string {
+	if credential == "" {
+		return ""
+	}
+	return Masked
+}
+
+// URLCredentials masks the password of the url, urls that fail to parse are
+// masked entirely as they may still contain credentials
+func URLCredentials(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Masked
+	}
+
+	return parsed.Redacted()
+}
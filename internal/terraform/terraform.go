@@ -43,6 +43,21 @@ func New(ctx context.Context, workingDir string) (*runner, error) {
 	}, err
 }
 
+// InDir creates a terraform runner for another working directory using the
+// terraform installed by the runner, environment variables are not copied
+func (r *runner) InDir(workingDir string) (*runner, error) {
+	tf, err := tfexec.NewTerraform(workingDir, r.runner.ExecPath())
+	if err != nil {
+		return nil, fmt.Errorf("error configuring terraform: %w", err)
+	}
+
+	return &runner{
+		installer:  r.installer,
+		runner:     tf,
+		workingDir: workingDir,
+	}, nil
+}
+
 // SetEnvVars sets environment variables to be used when invoking terraform
 func (r *runner) SetEnvVars(envVars map[string]string) error {
 	env := make(map[string]string)
@@ -0,0 +1,48 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+)
+
+const addonStateInterval = 30 * time.Second
+
+// AddonState returns the installation state of the addon on the cluster
+func (c *Client) AddonState(ctx context.Context, clusterID, addonID string) (string, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().Addoninstallation(addonID).Get().SendContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get addon %q installation for cluster id %q: %v", addonID, clusterID, err)
+	}
+	return string(response.Body().State()), nil
+}
+
+// WaitForAddonReady waits for the addon installed on the cluster to reach a ready state
+func (c *Client) WaitForAddonReady(ctx context.Context, clusterID, addonID string, timeout time.Duration) error {
+	return pollAddonReady(ctx, clusterID, addonID, c.AddonState, addonStateInterval, timeout)
+}
+
+// pollAddonReady invokes getState until the addon is ready, failed or the timeout is reached
+func pollAddonReady(ctx context.Context, clusterID, addonID string, getState func(context.Context, string, string) (string, error), interval, timeout time.Duration) error {
+	var state string
+
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		var err error
+		state, err = getState(ctx, clusterID, addonID)
+		if err != nil {
+			return false, err
+		}
+		if state == string(cmv1.AddOnInstallationStateFailed) {
+			return false, fmt.Errorf("addon %q installation for cluster id %q failed", addonID, clusterID)
+		}
+		return state == string(cmv1.AddOnInstallationStateReady), nil
+	}, wait.WithImmediate(), wait.WithInterval(interval), wait.WithTimeout(timeout), wait.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("addon %q for cluster id %q never reached a ready state (last state %q): %w", addonID, clusterID, state, err)
+	}
+
+	return nil
+}
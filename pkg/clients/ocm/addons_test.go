@@ -0,0 +1,39 @@
+package ocm
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("addon", func() {
+	It("should wait until the addon installation is ready", func(ctx context.Context) {
+		states := []string{"pending", "installing", "ready"}
+		calls := 0
+		getState := func(context.Context, string, string) (string, error) {
+			state := states[calls]
+			calls++
+			return state, nil
+		}
+
+		err := pollAddonReady(ctx, "123", "test-addon", getState, 10*time.Millisecond, time.Second)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(Equal(3))
+	})
+
+	It("should stop waiting when the addon installation fails", func(ctx context.Context) {
+		states := []string{"installing", "failed", "ready"}
+		calls := 0
+		getState := func(context.Context, string, string) (string, error) {
+			state := states[calls]
+			calls++
+			return state, nil
+		}
+
+		err := pollAddonReady(ctx, "123", "test-addon", getState, 10*time.Millisecond, time.Second)
+		Expect(err).Should(MatchError(ContainSubstring("failed")))
+		Expect(calls).Should(Equal(2))
+	})
+})
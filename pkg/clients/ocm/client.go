@@ -2,10 +2,14 @@ package ocm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 
 	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 )
 
 type Environment string
@@ -20,8 +24,14 @@ const (
 	fedrampTokenURL    string      = "https://sso.int.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token"
 )
 
+// Client is an ocm connection that can be rebuilt when its credentials are no
+// longer valid, see RetryOnAuthError
 type Client struct {
 	*ocmsdk.Connection
+
+	// reconnectMutex serializes rebuilding the connection
+	reconnectMutex sync.Mutex
+	connect        func(ctx context.Context) (*ocmsdk.Connection, error)
 }
 
 func New(ctx context.Context,
@@ -30,19 +40,88 @@ func New(ctx context.Context,
 	clientSecret string,
 	environment Environment,
 ) (*Client, error) {
-	connectionBuilder := ocmsdk.NewConnectionBuilder().URL(string(environment))
+	if err := environment.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to create ocm connection: %w", err)
+	}
+	environment = environment.Normalize()
+
+	connect := func(ctx context.Context) (*ocmsdk.Connection, error) {
+		connectionBuilder := ocmsdk.NewConnectionBuilder().URL(string(environment))
 
-	if strings.Contains(string(environment), "fr") {
-		connectionBuilder.Client(clientID, clientSecret).
-			TokenURL(fedrampTokenURL)
-	} else {
-		connectionBuilder.Client(clientID, clientSecret)
+		if strings.Contains(string(environment), "fr") {
+			connectionBuilder.Client(clientID, clientSecret).
+				TokenURL(fedrampTokenURL)
+		} else {
+			connectionBuilder.Client(clientID, clientSecret)
+		}
+
+		return connectionBuilder.BuildContext(ctx)
 	}
 
-	connection, err := connectionBuilder.BuildContext(ctx)
+	connection, err := connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ocm connection: %w", err)
 	}
 
-	return &Client{connection}, nil
+	return &Client{Connection: connection, connect: connect}, nil
+}
+
+// Reconnect closes the current ocm connection and builds a new one using the
+// credentials the client was constructed with. The connection is replaced in
+// place, requests already using the previous connection fail once it is closed
+func (c *Client) Reconnect(ctx context.Context) error {
+	c.reconnectMutex.Lock()
+	defer c.reconnectMutex.Unlock()
+
+	if c.connect == nil {
+		return errors.New("failed to recreate ocm connection: client was not created with New")
+	}
+
+	connection, err := c.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to recreate ocm connection: %w", err)
+	}
+
+	previous := c.Connection
+	c.Connection = connection
+	if previous != nil {
+		_ = previous.Close()
+	}
+
+	return nil
+}
+
+// RetryOnAuthError invokes fn and, if it fails with an authentication error,
+// rebuilds the ocm connection once and invokes fn again. Long running
+// operations can outlive the connection's tokens
+//
+//	err := client.RetryOnAuthError(ctx, func(ctx context.Context) error {
+//		_, err := client.ClustersMgmt().V1().Clusters().Cluster(id).Get().SendContext(ctx)
+//		return err
+//	})
+func (c *Client) RetryOnAuthError(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err == nil || !isAuthError(err) {
+		return err
+	}
+
+	if reconnectErr := c.Reconnect(ctx); reconnectErr != nil {
+		return fmt.Errorf("%w (after authentication error: %v)", reconnectErr, err)
+	}
+
+	return fn(ctx)
+}
+
+// isAuthError returns true when the error indicates the ocm credentials are no longer valid
+func isAuthError(err error) bool {
+	var ocmErr *ocmerrors.Error
+	if errors.As(err, &ocmErr) {
+		return ocmErr.Status() == http.StatusUnauthorized
+	}
+
+	// token refresh failures are not returned as ocm errors
+	message := err.Error()
+	return strings.Contains(message, "status is 401") ||
+		strings.Contains(message, "can't get access token") ||
+		strings.Contains(message, "invalid_grant")
 }
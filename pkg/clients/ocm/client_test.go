@@ -0,0 +1,96 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("authentication errors", func() {
+	var (
+		client       *Client
+		reconnects   int
+		unauthorized error
+	)
+
+	BeforeEach(func() {
+		reconnects = 0
+		client = &Client{connect: func(context.Context) (*ocmsdk.Connection, error) {
+			reconnects++
+			return nil, nil
+		}}
+		var err error
+		unauthorized, err = ocmerrors.NewError().Status(401).Reason("token is expired").Build()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should reconnect and retry after an authentication error", func(ctx context.Context) {
+		calls := 0
+		err := client.RetryOnAuthError(ctx, func(context.Context) error {
+			calls++
+			if calls == 1 {
+				return unauthorized
+			}
+			return nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(Equal(2))
+		Expect(reconnects).Should(Equal(1))
+	})
+
+	It("should only reconnect once", func(ctx context.Context) {
+		calls := 0
+		err := client.RetryOnAuthError(ctx, func(context.Context) error {
+			calls++
+			return unauthorized
+		})
+		Expect(err).Should(MatchError(unauthorized))
+		Expect(calls).Should(Equal(2))
+		Expect(reconnects).Should(Equal(1))
+	})
+
+	It("should not reconnect on other errors", func(ctx context.Context) {
+		calls := 0
+		err := client.RetryOnAuthError(ctx, func(context.Context) error {
+			calls++
+			return errors.New("cluster not found")
+		})
+		Expect(err).Should(HaveOccurred())
+		Expect(calls).Should(Equal(1))
+		Expect(reconnects).Should(Equal(0))
+	})
+})
+
+var _ = Describe("reconnect", func() {
+	It("should serialize concurrent reconnects", func(ctx context.Context) {
+		var reconnects atomic.Int32
+		client := &Client{connect: func(context.Context) (*ocmsdk.Connection, error) {
+			reconnects.Add(1)
+			return nil, nil
+		}}
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(client.Reconnect(ctx)).Should(Succeed())
+			}()
+		}
+		wg.Wait()
+
+		Expect(reconnects.Load()).Should(BeEquivalentTo(8))
+	})
+
+	It("should fail for a client not created with New", func(ctx context.Context) {
+		client := &Client{}
+		Expect(client.Reconnect(ctx)).Should(MatchError(ContainSubstring("not created with New")))
+	})
+})
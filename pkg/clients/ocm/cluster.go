@@ -0,0 +1,34 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ClusterProduct returns the product (e.g. rosa, osd) and billing model
+// (e.g. standard, marketplace-aws) the cluster was created with
+func (c *Client) ClusterProduct(ctx context.Context, clusterID string) (product, billingModel string, err error) {
+	var cluster *cmv1.Cluster
+	err = c.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		cluster = response.Body()
+		return nil
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+
+	product, billingModel = ClusterProductOf(cluster)
+	return product, billingModel, nil
+}
+
+// ClusterProductOf returns the product and billing model of the cluster object,
+// used when the cluster was already retrieved from ocm
+func ClusterProductOf(cluster *cmv1.Cluster) (string, string) {
+	return cluster.Product().ID(), string(cluster.BillingModel())
+}
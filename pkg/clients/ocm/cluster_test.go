@@ -0,0 +1,32 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("cluster product", func() {
+	DescribeTable("should return the product and billing model",
+		func(builder *cmv1.ClusterBuilder, expectedProduct, expectedBillingModel string) {
+			cluster, err := builder.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			product, billingModel := ClusterProductOf(cluster)
+			Expect(product).Should(Equal(expectedProduct))
+			Expect(billingModel).Should(Equal(expectedBillingModel))
+		},
+		Entry("rosa standard",
+			cmv1.NewCluster().Product(cmv1.NewProduct().ID("rosa")).BillingModel(cmv1.BillingModelStandard),
+			"rosa", "standard"),
+		Entry("rosa marketplace",
+			cmv1.NewCluster().Product(cmv1.NewProduct().ID("rosa")).BillingModel(cmv1.BillingModelMarketplaceAWS),
+			"rosa", "marketplace-aws"),
+		Entry("osd marketplace",
+			cmv1.NewCluster().Product(cmv1.NewProduct().ID("osd")).BillingModel(cmv1.BillingModelMarketplaceGCP),
+			"osd", "marketplace-gcp"),
+		Entry("unset",
+			cmv1.NewCluster(),
+			"", ""),
+	)
+})
@@ -0,0 +1,55 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	openshiftclient "github.com/openshift/osde2e-common/pkg/clients/openshift"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+// ClusterClient returns an openshift client built from the clusters kubeconfig.
+// Getting the kubeconfig and constructing the client are retried up to the
+// attempts, the cluster api can be unreachable for a while after install
+func (c *Client) ClusterClient(ctx context.Context, clusterID string, strategy poll.Strategy, attempts int, logger logr.Logger) (*openshiftclient.Client, error) {
+	kubeconfigFile := func(ctx context.Context) (string, error) {
+		return c.KubeconfigFile(ctx, clusterID, os.TempDir())
+	}
+	return clusterClient(ctx, clusterID, strategy, attempts, logger, kubeconfigFile, openshiftclient.NewFromKubeconfig)
+}
+
+// clusterClient retries writing the kubeconfig file and constructing the client from it
+func clusterClient(
+	ctx context.Context,
+	clusterID string,
+	strategy poll.Strategy,
+	attempts int,
+	logger logr.Logger,
+	kubeconfigFile func(context.Context) (string, error),
+	newClient func(string, logr.Logger) (*openshiftclient.Client, error),
+) (*openshiftclient.Client, error) {
+	var client *openshiftclient.Client
+
+	err := poll.Retry(ctx, strategy, attempts, func(ctx context.Context) error {
+		filename, err := kubeconfigFile(ctx)
+		if err != nil {
+			logger.Info("Failed to get cluster kubeconfig", "cluster_id", clusterID, "error", err.Error())
+			return err
+		}
+
+		client, err = newClient(filename, logger)
+		if err != nil {
+			logger.Info("Failed to construct cluster client", "cluster_id", clusterID, "error", err.Error())
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct client for cluster %q: %w", clusterID, err)
+	}
+
+	return client, nil
+}
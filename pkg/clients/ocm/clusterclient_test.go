@@ -0,0 +1,55 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	openshiftclient "github.com/openshift/osde2e-common/pkg/clients/openshift"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+var _ = Describe("cluster client", func() {
+	var (
+		strategy       poll.Strategy
+		kubeconfigFile func(context.Context) (string, error)
+		constructions  int
+	)
+
+	BeforeEach(func() {
+		strategy = poll.Strategy{Interval: time.Millisecond}
+		kubeconfigFile = func(context.Context) (string, error) { return "test-kubeconfig", nil }
+		constructions = 0
+	})
+
+	It("should retry when the client construction fails on the first attempt", func(ctx context.Context) {
+		expected := &openshiftclient.Client{}
+		newClient := func(filename string, _ logr.Logger) (*openshiftclient.Client, error) {
+			Expect(filename).Should(Equal("test-kubeconfig"))
+			constructions++
+			if constructions == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return expected, nil
+		}
+
+		client, err := clusterClient(ctx, "123", strategy, 3, logr.Discard(), kubeconfigFile, newClient)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(client).Should(BeIdenticalTo(expected))
+		Expect(constructions).Should(Equal(2))
+	})
+
+	It("should fail once the attempts are exhausted", func(ctx context.Context) {
+		newClient := func(string, logr.Logger) (*openshiftclient.Client, error) {
+			constructions++
+			return nil, errors.New("connection refused")
+		}
+
+		_, err := clusterClient(ctx, "123", strategy, 2, logr.Discard(), kubeconfigFile, newClient)
+		Expect(err).Should(MatchError(ContainSubstring("connection refused")))
+		Expect(constructions).Should(Equal(2))
+	})
+})
@@ -2,12 +2,39 @@ package ocm
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/e2e-framework/klient/wait"
 )
 
-// getKubeconfig returns the clusters kubeconfig content
-func (c *Client) getKubeconfig(ctx context.Context, clusterID string) (string, error) {
+const (
+	kubeconfigRetryInterval = 10 * time.Second
+	kubeconfigRetryTimeout  = 5 * time.Minute
+)
+
+// ErrAdminCredentialsUnavailable is returned when ocm has no kubeadmin credentials
+// for the cluster, e.g. sts clusters
+var ErrAdminCredentialsUnavailable = errors.New("admin credentials are not available")
+
+// clusterCredentials is the ocm cluster credentials resource, the sdk only
+// models its kubeconfig
+type clusterCredentials struct {
+	Admin struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	} `json:"admin"`
+}
+
+// fetchKubeconfig returns the clusters kubeconfig content as currently reported by ocm
+func (c *Client) fetchKubeconfig(ctx context.Context, clusterID string) (string, error) {
 	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Credentials().Get().SendContext(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get credentials for cluster id %q: %v", clusterID, err)
@@ -15,24 +42,156 @@ func (c *Client) getKubeconfig(ctx context.Context, clusterID string) (string, e
 	return response.Body().Kubeconfig(), nil
 }
 
-// GetKubeconfig returns the clusters kubeconfig file
-func (c *Client) KubeconfigFile(ctx context.Context, clusterID, directory string) (string, error) {
-	filename := fmt.Sprintf("%s/%s-kubeconfig", directory, clusterID)
+// getKubeconfig returns the clusters kubeconfig content, retrying until ocm
+// returns a kubeconfig that is complete
+func (c *Client) getKubeconfig(ctx context.Context, clusterID string) (string, error) {
+	return pollKubeconfig(ctx, clusterID, c.fetchKubeconfig, kubeconfigRetryInterval, kubeconfigRetryTimeout)
+}
+
+// pollKubeconfig invokes fetch until it returns a valid kubeconfig or the timeout is reached.
+// Credentials can be briefly empty or partial right after a cluster reaches a ready state
+func pollKubeconfig(ctx context.Context, clusterID string, fetch func(context.Context, string) (string, error), interval, timeout time.Duration) (string, error) {
+	var (
+		kubeconfig string
+		lastErr    error
+	)
+
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		kubeconfig, lastErr = fetch(ctx, clusterID)
+		if lastErr != nil {
+			return false, nil
+		}
+		if lastErr = validateKubeconfig(kubeconfig); lastErr != nil {
+			return false, nil
+		}
+		return true, nil
+	}, wait.WithImmediate(), wait.WithInterval(interval), wait.WithTimeout(timeout), wait.WithContext(ctx))
+	if err != nil {
+		if lastErr != nil {
+			return "", fmt.Errorf("kubeconfig for cluster id %q was not available within %s: %w", clusterID, timeout, lastErr)
+		}
+		return "", fmt.Errorf("kubeconfig for cluster id %q was not available within %s: %w", clusterID, timeout, err)
+	}
+
+	return kubeconfig, nil
+}
+
+// validateKubeconfig verifies the kubeconfig content is non-empty and parses
+func validateKubeconfig(kubeconfig string) error {
+	if kubeconfig == "" {
+		return errors.New("kubeconfig is empty")
+	}
+
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	if err = clientcmd.Validate(*config); err != nil {
+		return fmt.Errorf("kubeconfig is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// KubeconfigFileOption configures the file KubeconfigFile writes
+type KubeconfigFileOption func(*kubeconfigFileOptions)
+
+// kubeconfigFileOptions represents the file KubeconfigFile writes
+type kubeconfigFileOptions struct {
+	filename string
+}
+
+// WithKubeconfigFilename sets the name of the kubeconfig file within the
+// directory, defaults to <clusterID>-kubeconfig
+func WithKubeconfigFilename(filename string) KubeconfigFileOption {
+	return func(o *kubeconfigFileOptions) {
+		o.filename = filename
+	}
+}
+
+// KubeconfigFile returns the clusters kubeconfig file. The file is written
+// atomically so readers and concurrent writers never observe a partial kubeconfig
+func (c *Client) KubeconfigFile(ctx context.Context, clusterID, directory string, opts ...KubeconfigFileOption) (string, error) {
+	options := &kubeconfigFileOptions{filename: fmt.Sprintf("%s-kubeconfig", clusterID)}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	filename := filepath.Join(directory, options.filename)
 
 	kubeconfig, err := c.getKubeconfig(ctx, clusterID)
 	if err != nil {
 		return filename, err
 	}
 
-	err = os.WriteFile(filename, []byte(kubeconfig), 0o600)
-	if err != nil {
+	if err = writeFileAtomic(filename, []byte(kubeconfig), 0o600); err != nil {
 		return filename, fmt.Errorf("failed to write kubeconfig file: %v", err)
 	}
 
 	return filename, nil
 }
 
+// writeFileAtomic writes the data to a temporary file in the same directory
+// and renames it into place
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	file, err := os.CreateTemp(filepath.Dir(filename), fmt.Sprintf(".%s-*", filepath.Base(filename)))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err = file.Chmod(perm); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filename)
+}
+
 // Kubeconfig returns the clusters kubeconfig content
 func (c *Client) Kubeconfig(ctx context.Context, clusterID string) (string, error) {
 	return c.getKubeconfig(ctx, clusterID)
 }
+
+// AdminCredentials returns the clusters kubeadmin username and password from
+// the ocm cluster credentials
+func (c *Client) AdminCredentials(ctx context.Context, clusterID string) (string, string, error) {
+	response, err := c.Get().
+		Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/credentials", url.PathEscape(clusterID))).
+		SendContext(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get credentials for cluster id %q: %v", clusterID, err)
+	}
+
+	if response.Status() != http.StatusOK {
+		return "", "", fmt.Errorf("failed to get credentials for cluster id %q: status %d: %s", clusterID, response.Status(), response.String())
+	}
+
+	return parseAdminCredentials(clusterID, response.Bytes())
+}
+
+// parseAdminCredentials returns the kubeadmin username and password of the credentials resource
+func parseAdminCredentials(clusterID string, body []byte) (string, string, error) {
+	var credentials clusterCredentials
+	if err := json.Unmarshal(body, &credentials); err != nil {
+		return "", "", fmt.Errorf("failed to parse credentials for cluster id %q: %v", clusterID, err)
+	}
+
+	if credentials.Admin.User == "" || credentials.Admin.Password == "" {
+		return "", "", fmt.Errorf("cluster id %q: %w", clusterID, ErrAdminCredentialsUnavailable)
+	}
+
+	return credentials.Admin.User, credentials.Admin.Password, nil
+}
@@ -0,0 +1,117 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const validKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://api.test.example.com:6443
+users:
+- name: admin
+  user:
+    token: abc123
+contexts:
+- name: admin
+  context:
+    cluster: test
+    user: admin
+current-context: admin
+`
+
+var _ = Describe("kubeconfig", func() {
+	It("should retry until a valid kubeconfig is returned", func(ctx context.Context) {
+		responses := []string{"", "apiVersion: v1\nkind: Config\ncurrent-context: missing\n", validKubeconfig}
+		calls := 0
+		fetch := func(context.Context, string) (string, error) {
+			response := responses[calls]
+			calls++
+			return response, nil
+		}
+
+		kubeconfig, err := pollKubeconfig(ctx, "123", fetch, 10*time.Millisecond, time.Second)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(kubeconfig).Should(Equal(validKubeconfig))
+		Expect(calls).Should(Equal(3))
+	})
+
+	It("should retry when ocm returns an error", func(ctx context.Context) {
+		calls := 0
+		fetch := func(context.Context, string) (string, error) {
+			calls++
+			if calls == 1 {
+				return "", errors.New("credentials not ready")
+			}
+			return validKubeconfig, nil
+		}
+
+		_, err := pollKubeconfig(ctx, "123", fetch, 10*time.Millisecond, time.Second)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(Equal(2))
+	})
+
+	It("should return the last error when the kubeconfig never becomes valid", func(ctx context.Context) {
+		fetch := func(context.Context, string) (string, error) {
+			return "", nil
+		}
+
+		_, err := pollKubeconfig(ctx, "123", fetch, 10*time.Millisecond, 50*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("kubeconfig is empty")))
+	})
+})
+
+var _ = Describe("admin credentials", func() {
+	It("should return the kubeadmin username and password", func() {
+		username, password, err := parseAdminCredentials("123", []byte(`{"kind": "ClusterCredentials", "admin": {"user": "kubeadmin", "password": "abc-123"}}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(username).Should(Equal("kubeadmin"))
+		Expect(password).Should(Equal("abc-123"))
+	})
+
+	It("should fail when the cluster has no admin credentials", func() {
+		_, _, err := parseAdminCredentials("123", []byte(`{"kind": "ClusterCredentials", "kubeconfig": "apiVersion: v1"}`))
+		Expect(errors.Is(err, ErrAdminCredentialsUnavailable)).Should(BeTrue())
+	})
+
+	It("should fail on an invalid response", func() {
+		_, _, err := parseAdminCredentials("123", []byte("not json"))
+		Expect(err).Should(MatchError(ContainSubstring("failed to parse credentials")))
+	})
+})
+
+var _ = Describe("kubeconfig file", func() {
+	It("should write the file atomically", func() {
+		directory := GinkgoT().TempDir()
+		filename := filepath.Join(directory, "123-kubeconfig")
+		Expect(os.WriteFile(filename, []byte("stale"), 0o644)).Should(Succeed())
+
+		Expect(writeFileAtomic(filename, []byte(validKubeconfig), 0o600)).Should(Succeed())
+
+		data, err := os.ReadFile(filename)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal(validKubeconfig))
+
+		info, err := os.Stat(filename)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(info.Mode().Perm()).Should(Equal(os.FileMode(0o600)))
+
+		entries, err := os.ReadDir(directory)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entries).Should(HaveLen(1))
+	})
+
+	It("should fail when the directory does not exist", func() {
+		filename := filepath.Join(GinkgoT().TempDir(), "missing", "123-kubeconfig")
+		Expect(writeFileAtomic(filename, []byte(validKubeconfig), 0o600)).ShouldNot(Succeed())
+	})
+})
@@ -0,0 +1,77 @@
+package ocm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// knownEnvironments are the ocm environments defined by this package
+var knownEnvironments = []Environment{
+	Production,
+	Stage,
+	Integration,
+	FedRampProduction,
+	FedRampStage,
+	FedRampIntegration,
+}
+
+// Normalize returns the environment with surrounding whitespace and trailing slashes removed
+func (e Environment) Normalize() Environment {
+	return Environment(strings.TrimRight(strings.TrimSpace(string(e)), "/"))
+}
+
+// Validate verifies the environment is either one of the known environments
+// or a well formed https url
+func (e Environment) Validate() error {
+	environment := e.Normalize()
+
+	for _, known := range knownEnvironments {
+		if environment == known {
+			return nil
+		}
+	}
+
+	parsedURL, err := url.Parse(string(environment))
+	if err != nil || parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return fmt.Errorf("invalid ocm environment %q: must be one of %v or a https url", string(e), knownEnvironments)
+	}
+
+	return nil
+}
+
+// shortEnvironments maps the short environment names to the known environments
+var shortEnvironments = map[string]Environment{
+	"prod":                Production,
+	"production":          Production,
+	"stage":               Stage,
+	"staging":             Stage,
+	"int":                 Integration,
+	"integration":         Integration,
+	"fedramp-prod":        FedRampProduction,
+	"fedramp-production":  FedRampProduction,
+	"fedramp-stage":       FedRampStage,
+	"fedramp-staging":     FedRampStage,
+	"fedramp-int":         FedRampIntegration,
+	"fedramp-integration": FedRampIntegration,
+}
+
+// ParseEnvironment returns the environment for the short name provided (e.g.
+// prod, stage, int, fedramp-int). Well formed https urls are returned as is
+//
+//	environment, err := ocm.ParseEnvironment(os.Getenv("OCM_ENV"))
+func ParseEnvironment(s string) (Environment, error) {
+	if environment, ok := shortEnvironments[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return environment, nil
+	}
+
+	environment := Environment(s).Normalize()
+	if strings.HasPrefix(string(environment), "https://") {
+		if err := environment.Validate(); err != nil {
+			return "", err
+		}
+		return environment, nil
+	}
+
+	return "", fmt.Errorf("unknown ocm environment %q: must be one of prod, stage, int, fedramp-prod, fedramp-stage, fedramp-int or a https url", s)
+}
@@ -0,0 +1,68 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("environment", func() {
+	DescribeTable("should be valid",
+		func(environment Environment) {
+			Expect(environment.Validate()).Should(Succeed())
+		},
+		Entry("production", Production),
+		Entry("stage", Stage),
+		Entry("fedramp integration", FedRampIntegration),
+		Entry("custom https url", Environment("https://api.custom.openshift.com")),
+		Entry("trailing slash", Environment("https://api.stage.openshift.com/")),
+	)
+
+	DescribeTable("should be invalid",
+		func(environment Environment) {
+			Expect(environment.Validate()).Should(MatchError(ContainSubstring("invalid ocm environment")))
+		},
+		Entry("short name", Environment("stage")),
+		Entry("typo", Environment("htps://api.openshift.com")),
+		Entry("http url", Environment("http://api.openshift.com")),
+		Entry("empty", Environment("")),
+	)
+
+	It("should normalize trailing slashes and whitespace", func() {
+		Expect(Environment(" https://api.openshift.com/ ").Normalize()).Should(Equal(Production))
+	})
+})
+
+var _ = Describe("parse environment", func() {
+	DescribeTable("should map short names",
+		func(name string, expected Environment) {
+			environment, err := ParseEnvironment(name)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(environment).Should(Equal(expected))
+		},
+		Entry("prod", "prod", Production),
+		Entry("production", "production", Production),
+		Entry("stage", "stage", Stage),
+		Entry("staging", "staging", Stage),
+		Entry("int", "int", Integration),
+		Entry("integration", "integration", Integration),
+		Entry("fedramp-prod", "fedramp-prod", FedRampProduction),
+		Entry("fedramp-production", "fedramp-production", FedRampProduction),
+		Entry("fedramp-stage", "fedramp-stage", FedRampStage),
+		Entry("fedramp-staging", "fedramp-staging", FedRampStage),
+		Entry("fedramp-int", "fedramp-int", FedRampIntegration),
+		Entry("fedramp-integration", "fedramp-integration", FedRampIntegration),
+		Entry("upper case", "STAGE", Stage),
+		Entry("https url", "https://api.stage.openshift.com/", Stage),
+	)
+
+	DescribeTable("should reject invalid names",
+		func(name string) {
+			_, err := ParseEnvironment(name)
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("unknown", "dev"),
+		Entry("http url", "http://api.openshift.com"),
+		Entry("malformed url", "https://"),
+	)
+})
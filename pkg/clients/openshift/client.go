@@ -3,6 +3,8 @@ package openshift
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/openshift/api"
@@ -13,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/e2e-framework/klient/conf"
 	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 )
@@ -34,7 +37,31 @@ func NewFromKubeconfig(filename string, logger logr.Logger) (*Client, error) {
 	return NewFromRestConfig(cfg, logger)
 }
 
+// NewFromKubeconfigContext returns a client for the named context of a kubeconfig
+// file that contains multiple contexts
+func NewFromKubeconfigContext(filename, contextName string, logger logr.Logger) (*Client, error) {
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: filename},
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes config for context %q: %w", contextName, err)
+	}
+	return NewFromRestConfig(cfg, logger)
+}
+
 func NewFromRestConfig(cfg *rest.Config, logger logr.Logger) (*Client, error) {
+	client, err := newResources(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{client, logger}, nil
+}
+
+// newResources returns the resources client for the config with the openshift
+// api schemes registered, every client is built with it so they all work with
+// the same resource types
+func newResources(cfg *rest.Config) (*resources.Resources, error) {
 	client, err := resources.New(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to created dynamic client: %w", err)
@@ -42,33 +69,73 @@ func NewFromRestConfig(cfg *rest.Config, logger logr.Logger) (*Client, error) {
 	if err = api.Install(client.GetScheme()); err != nil {
 		return nil, fmt.Errorf("unable to register openshift api schemes: %w", err)
 	}
-	return &Client{client, logger}, nil
+	return client, nil
 }
 
+// serviceAccountUserPrefix prefixes the usernames of service accounts
+const serviceAccountUserPrefix = "system:serviceaccount:"
+
 // Impersonate returns a copy of the client with a new ImpersonationConfig
-// established on the underlying client, acting as the provided user
+// established on the underlying client, acting as the provided user. Service
+// accounts are impersonated by their username, system:serviceaccount:<namespace>:<name>.
+// An empty user with no groups removes the impersonation
 //
 //	backplaneUser, _ := oc.Impersonate("test-user@redhat.com", "dedicated-admins")
 func (c *Client) Impersonate(user string, groups ...string) (*Client, error) {
-	if user != "" {
-		// these groups are required for impersonating a user
-		groups = append(groups, "system:authenticated", "system:authenticated:oauth")
+	impersonationConfig, err := newImpersonationConfig(user, groups...)
+	if err != nil {
+		return nil, err
 	}
 
 	client := *c
 	newRestConfig := rest.CopyConfig(c.Resources.GetConfig())
-	newRestConfig.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
-	newResources, err := resources.New(newRestConfig)
+	newRestConfig.Impersonate = impersonationConfig
+	client.Resources, err = newResources(newRestConfig)
 	if err != nil {
 		return nil, err
 	}
-	client.Resources = newResources
 
-	if err = api.Install(client.GetScheme()); err != nil {
-		return nil, fmt.Errorf("unable to register openshift api schemes: %w", err)
+	return &client, nil
+}
+
+// newImpersonationConfig returns the impersonation of the user and groups. Human
+// users get the groups of an oauth authenticated user and service accounts the
+// groups of their namespace. The api server rejects impersonating groups without
+// a user, so group only impersonation returns an error
+func newImpersonationConfig(user string, groups ...string) (rest.ImpersonationConfig, error) {
+	if user == "" {
+		if len(groups) != 0 {
+			return rest.ImpersonationConfig{}, fmt.Errorf("impersonating groups %v requires a user", groups)
+		}
+		return rest.ImpersonationConfig{}, nil
 	}
 
-	return &client, nil
+	if namespace, ok := strings.CutPrefix(user, serviceAccountUserPrefix); ok {
+		namespace, name, found := strings.Cut(namespace, ":")
+		if !found || namespace == "" || name == "" {
+			return rest.ImpersonationConfig{}, fmt.Errorf("invalid service account user %q, expected %s<namespace>:<name>", user, serviceAccountUserPrefix)
+		}
+		// these groups are required for impersonating a service account
+		groups = append(groups, "system:serviceaccounts", "system:serviceaccounts:"+namespace, "system:authenticated")
+	} else {
+		// these groups are required for impersonating a user
+		groups = append(groups, "system:authenticated", "system:authenticated:oauth")
+	}
+
+	return rest.ImpersonationConfig{UserName: user, Groups: uniqueGroups(groups)}, nil
+}
+
+// uniqueGroups returns the groups without duplicates, keeping their order
+func uniqueGroups(groups []string) []string {
+	seen := make(map[string]bool, len(groups))
+	unique := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			unique = append(unique, group)
+		}
+	}
+	return unique
 }
 
 // GetPodLogs fetches the logs of a pod's default container
@@ -84,15 +151,36 @@ func (c *Client) GetPodLogs(ctx context.Context, name, namespace string) (string
 	return string(logData), nil
 }
 
-// GetJobLogs fetches the logs of a job's first container
+// GetJobLogs fetches the logs of a job's pods. When the job has created
+// multiple pods (e.g. retries), logs from each pod are returned newest first
 func (c *Client) GetJobLogs(ctx context.Context, name, namespace string) (string, error) {
 	pods := new(corev1.PodList)
-	err := c.List(ctx, pods, resources.WithLabelSelector(labels.FormatLabels(map[string]string{"job-name": name})))
+	err := c.WithNamespace(namespace).List(ctx, pods, resources.WithLabelSelector(labels.FormatLabels(map[string]string{"job-name": name})))
 	if err != nil {
 		return "", fmt.Errorf("failed to list pods for job %s in %s namespace: %w", name, namespace, err)
 	}
-	// TODO: there may be a case where the first item isn't correct
-	return c.GetPodLogs(ctx, pods.Items[0].GetName(), namespace)
+
+	switch len(pods.Items) {
+	case 0:
+		return "", fmt.Errorf("no pods found for job %s in %s namespace", name, namespace)
+	case 1:
+		return c.GetPodLogs(ctx, pods.Items[0].GetName(), namespace)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+
+	var logs strings.Builder
+	for _, pod := range pods.Items {
+		podLogs, err := c.GetPodLogs(ctx, pod.GetName(), namespace)
+		if err != nil {
+			podLogs = err.Error()
+		}
+		fmt.Fprintf(&logs, "==> pod %s/%s (%s) <==\n%s\n", namespace, pod.GetName(), pod.Status.Phase, podLogs)
+	}
+
+	return logs.String(), nil
 }
 
 // WatchJob function streams job events and returns nil on success.
@@ -0,0 +1,99 @@
+package openshift
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+const multiContextKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: first
+  cluster:
+    server: https://api.first.example.com:6443
+- name: second
+  cluster:
+    server: https://api.second.example.com:6443
+users:
+- name: admin
+  user:
+    token: abc123
+- name: viewer
+  user:
+    token: def456
+contexts:
+- name: first-admin
+  context:
+    cluster: first
+    user: admin
+- name: second-viewer
+  context:
+    cluster: second
+    user: viewer
+current-context: first-admin
+`
+
+var _ = Describe("kubeconfig context", func() {
+	var filename string
+
+	BeforeEach(func() {
+		filename = filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(filename, []byte(multiContextKubeconfig), 0o600)).Should(Succeed())
+	})
+
+	It("should select the named context", func() {
+		client, err := NewFromKubeconfigContext(filename, "second-viewer", logr.Discard())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(client.GetConfig().Host).Should(Equal("https://api.second.example.com:6443"))
+		Expect(client.GetConfig().BearerToken).Should(Equal("def456"))
+	})
+
+	It("should register the openshift api schemes on impersonated clients", func() {
+		client, err := NewFromKubeconfig(filename, logr.Discard())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		impersonated, err := client.Impersonate("test-user")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		route := schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+		Expect(client.GetScheme().Recognizes(route)).Should(BeTrue())
+		Expect(impersonated.GetScheme().Recognizes(route)).Should(BeTrue())
+	})
+
+	It("should fail when the context does not exist", func() {
+		_, err := NewFromKubeconfigContext(filename, "missing", logr.Discard())
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("impersonation", func() {
+	DescribeTable("should build the impersonation config",
+		func(user string, groups []string, expected rest.ImpersonationConfig) {
+			impersonationConfig, err := newImpersonationConfig(user, groups...)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(impersonationConfig).Should(Equal(expected))
+		},
+		Entry("of a user", "test-user@redhat.com", []string{"dedicated-admins"},
+			rest.ImpersonationConfig{UserName: "test-user@redhat.com", Groups: []string{"dedicated-admins", "system:authenticated", "system:authenticated:oauth"}}),
+		Entry("of a service account", "system:serviceaccount:openshift-monitoring:prometheus-k8s", nil,
+			rest.ImpersonationConfig{UserName: "system:serviceaccount:openshift-monitoring:prometheus-k8s", Groups: []string{"system:serviceaccounts", "system:serviceaccounts:openshift-monitoring", "system:authenticated"}}),
+		Entry("without duplicate groups", "test-user@redhat.com", []string{"system:authenticated"},
+			rest.ImpersonationConfig{UserName: "test-user@redhat.com", Groups: []string{"system:authenticated", "system:authenticated:oauth"}}),
+		Entry("of no one", "", nil, rest.ImpersonationConfig{}),
+	)
+
+	DescribeTable("should reject invalid impersonation",
+		func(user string, groups []string) {
+			_, err := newImpersonationConfig(user, groups...)
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("of groups without a user", "", []string{"dedicated-admins"}),
+		Entry("of a service account without a name", "system:serviceaccount:openshift-monitoring", nil),
+	)
+})
@@ -0,0 +1,58 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// clusterVersionName is the name of the cluster scoped cluster version resource
+const clusterVersionName = "version"
+
+// GetClusterVersion returns the clusters cluster version resource
+func (c Client) GetClusterVersion(ctx context.Context) (*configv1.ClusterVersion, error) {
+	var clusterVersion configv1.ClusterVersion
+	if err := c.Get(ctx, clusterVersionName, "", &clusterVersion); err != nil {
+		return nil, fmt.Errorf("failed to get cluster version %q: %w", clusterVersionName, err)
+	}
+	return &clusterVersion, nil
+}
+
+// CurrentVersion returns the most recent openshift version the cluster completed an update to
+func (c Client) CurrentVersion(ctx context.Context) (string, error) {
+	clusterVersion, err := c.GetClusterVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	return currentVersion(clusterVersion)
+}
+
+// AvailableUpdates returns the openshift versions the cluster can be updated to
+func (c Client) AvailableUpdates(ctx context.Context) ([]string, error) {
+	clusterVersion, err := c.GetClusterVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return availableUpdates(clusterVersion), nil
+}
+
+// currentVersion returns the version of the newest completed update in the history
+func currentVersion(clusterVersion *configv1.ClusterVersion) (string, error) {
+	// history is ordered newest first
+	for _, update := range clusterVersion.Status.History {
+		if update.State == configv1.CompletedUpdate {
+			return update.Version, nil
+		}
+	}
+	return "", fmt.Errorf("cluster version %q has no completed update", clusterVersion.GetName())
+}
+
+// availableUpdates returns the versions of the available updates
+func availableUpdates(clusterVersion *configv1.ClusterVersion) []string {
+	versions := make([]string, 0, len(clusterVersion.Status.AvailableUpdates))
+	for _, release := range clusterVersion.Status.AvailableUpdates {
+		versions = append(versions, release.Version)
+	}
+	return versions
+}
@@ -0,0 +1,35 @@
+package openshift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+var _ = Describe("cluster version", func() {
+	It("should return the newest completed version", func() {
+		version, err := currentVersion(&configv1.ClusterVersion{Status: configv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{
+				{State: configv1.PartialUpdate, Version: "4.15.2"},
+				{State: configv1.CompletedUpdate, Version: "4.15.1"},
+				{State: configv1.CompletedUpdate, Version: "4.14.9"},
+			},
+		}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version).Should(Equal("4.15.1"))
+	})
+
+	It("should fail without a completed update", func() {
+		_, err := currentVersion(&configv1.ClusterVersion{Status: configv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{{State: configv1.PartialUpdate, Version: "4.15.0"}},
+		}})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should return the available update versions", func() {
+		Expect(availableUpdates(&configv1.ClusterVersion{Status: configv1.ClusterVersionStatus{
+			AvailableUpdates: []configv1.Release{{Version: "4.15.2"}, {Version: "4.15.3"}},
+		}})).Should(Equal([]string{"4.15.2", "4.15.3"}))
+		Expect(availableUpdates(&configv1.ClusterVersion{})).Should(BeEmpty())
+	})
+})
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 	"sigs.k8s.io/e2e-framework/klient/wait"
 )
 
@@ -16,48 +18,219 @@ const (
 	osdClusterReadyName      = "osd-cluster-ready"
 	osdClusterReadyNamespace = "openshift-monitoring"
 	jobNameLoggerKey         = "job_name"
+	jobNamespaceLoggerKey    = "job_namespace"
+	jobSelectorLoggerKey     = "job_selector"
 	timeoutLoggerKey         = "timeout"
 )
 
+// HealthCheckOption configures the jobs OSDClusterHealthy waits on
+type HealthCheckOption func(*healthCheckOptions)
+
+// JobCompletion returns true when the job has completed successfully
+type JobCompletion func(ctx context.Context, job batchv1.Job) (bool, error)
+
+// healthCheckOptions represents the jobs OSDClusterHealthy waits on
+type healthCheckOptions struct {
+	jobName       string
+	jobNamespace  string
+	jobSelector   string
+	jobCompletion JobCompletion
+
+	maxNotReadyNodes int
+}
+
+// WithHealthCheckJobName sets the name of the job to wait on, defaults to osd-cluster-ready
+func WithHealthCheckJobName(name string) HealthCheckOption {
+	return func(o *healthCheckOptions) {
+		o.jobName = name
+	}
+}
+
+// WithHealthCheckJobNamespace sets the namespace of the job to wait on, defaults to openshift-monitoring
+func WithHealthCheckJobNamespace(namespace string) HealthCheckOption {
+	return func(o *healthCheckOptions) {
+		o.jobNamespace = namespace
+	}
+}
+
+// WithHealthCheckJobSelector waits on every job in the namespace matching the
+// label selector instead of a single named job
+func WithHealthCheckJobSelector(selector string) HealthCheckOption {
+	return func(o *healthCheckOptions) {
+		o.jobSelector = selector
+	}
+}
+
+// WithHealthCheckJobCompletion sets the predicate deciding when a job has
+// completed successfully, defaults to the job having a complete condition.
+// Errors returned by the predicate fail the health check
+func WithHealthCheckJobCompletion(completion JobCompletion) HealthCheckOption {
+	return func(o *healthCheckOptions) {
+		o.jobCompletion = completion
+	}
+}
+
+// WithMaxNotReadyNodes allows HCPClusterHealthy to pass with up to the number
+// of nodes not ready, e.g. during rolling operations. Defaults to 0
+func WithMaxNotReadyNodes(count int) HealthCheckOption {
+	return func(o *healthCheckOptions) {
+		o.maxNotReadyNodes = count
+	}
+}
+
+// JobCompleteWithLog returns a job completion predicate requiring the job to
+// be complete and its logs to contain the marker
+func (c *Client) JobCompleteWithLog(marker string) JobCompletion {
+	return func(ctx context.Context, job batchv1.Job) (bool, error) {
+		if !jobComplete(job) {
+			return false, nil
+		}
+		logs, err := c.GetJobLogs(ctx, job.GetName(), job.GetNamespace())
+		if err != nil {
+			return false, fmt.Errorf("unable to get job logs for %s/%s: %w", job.GetNamespace(), job.GetName(), err)
+		}
+		return strings.Contains(logs, marker), nil
+	}
+}
+
+// healthCheckJobs returns the jobs the health check waits on
+func (c *Client) healthCheckJobs(ctx context.Context, options *healthCheckOptions) ([]batchv1.Job, error) {
+	if options.jobSelector == "" {
+		job := new(batchv1.Job)
+		if err := c.Get(ctx, options.jobName, options.jobNamespace, job); err != nil {
+			return nil, err
+		}
+		return []batchv1.Job{*job}, nil
+	}
+
+	jobs := new(batchv1.JobList)
+	if err := c.WithNamespace(options.jobNamespace).List(ctx, jobs, resources.WithLabelSelector(options.jobSelector)); err != nil {
+		return nil, err
+	}
+	return jobs.Items, nil
+}
+
+// jobComplete returns true when the job has a true complete condition
+func jobComplete(job batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobsComplete returns true when there are jobs and each of them is complete
+func jobsComplete(ctx context.Context, jobs []batchv1.Job, completion JobCompletion) (bool, error) {
+	if len(jobs) == 0 {
+		return false, nil
+	}
+	for _, job := range jobs {
+		complete, err := completion(ctx, job)
+		if err != nil || !complete {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // OSDClusterHealthy waits for the cluster to be in a healthy "ready" state
-// by confirming the osd-ready-job finishes successfully
-func (c *Client) OSDClusterHealthy(ctx context.Context, reportDir string, timeout time.Duration) error {
+// by confirming the osd-ready-job finishes successfully. Options can be
+// provided to wait on a different job or a set of label selected jobs
+//
+//	err := client.OSDClusterHealthy(ctx, reportDir, timeout, WithHealthCheckJobNamespace("custom-ready"))
+func (c *Client) OSDClusterHealthy(ctx context.Context, reportDir string, timeout time.Duration, opts ...HealthCheckOption) error {
+	options := &healthCheckOptions{
+		jobName:      osdClusterReadyName,
+		jobNamespace: osdClusterReadyNamespace,
+		jobCompletion: func(_ context.Context, job batchv1.Job) (bool, error) {
+			return jobComplete(job), nil
+		},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	namespace := options.jobNamespace
+	description := fmt.Sprintf("%s/%s", namespace, options.jobName)
+	if options.jobSelector != "" {
+		description = fmt.Sprintf("%s/[%s]", namespace, options.jobSelector)
+	}
+
 	if err := wait.For(func(ctx context.Context) (bool, error) {
-		job := new(batchv1.Job)
-		if err := c.Get(ctx, osdClusterReadyName, osdClusterReadyNamespace, job); err != nil {
-			c.log.Error(err, fmt.Sprintf("failed to get job %s/%s", osdClusterReadyNamespace, osdClusterReadyName))
+		jobs, err := c.healthCheckJobs(ctx, options)
+		if err != nil {
+			c.log.Error(err, fmt.Sprintf("failed to get job %s", description))
 			if isRetryableAPIError(err) || apierrors.IsNotFound(err) {
 				return false, nil
 			}
 			return false, err
 		}
-		for _, cond := range job.Status.Conditions {
-			if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
-				return true, nil
-			}
-		}
-		return false, nil
+		return jobsComplete(ctx, jobs, options.jobCompletion)
 	}, wait.WithTimeout(timeout)); err != nil {
 		c.log.Error(err, "failed waiting for healthcheck job to finish")
-		logs, err := c.GetJobLogs(ctx, osdClusterReadyName, osdClusterReadyNamespace)
-		if err != nil {
-			return fmt.Errorf("unable to get job logs for %s/%s: %w", osdClusterReadyNamespace, osdClusterReadyName, err)
+
+		jobNames := []string{options.jobName}
+		if options.jobSelector != "" {
+			jobNames = nil
+			jobs, listErr := c.healthCheckJobs(ctx, options)
+			if listErr != nil {
+				return fmt.Errorf("unable to list jobs %s: %w", description, listErr)
+			}
+			for _, job := range jobs {
+				if complete, _ := options.jobCompletion(ctx, job); !complete {
+					jobNames = append(jobNames, job.GetName())
+				}
+			}
 		}
-		jobLogsFile := fmt.Sprintf("%s/%s.log", reportDir, osdClusterReadyName)
-		if err = os.WriteFile(jobLogsFile, []byte(logs), os.FileMode(0o644)); err != nil {
-			return fmt.Errorf("failed to write job %s logs to file: %w", osdClusterReadyName, err)
+
+		var jobLogsFiles []string
+		for _, name := range jobNames {
+			logs, logsErr := c.GetJobLogs(ctx, name, namespace)
+			if logsErr != nil {
+				return fmt.Errorf("unable to get job logs for %s/%s: %w", namespace, name, logsErr)
+			}
+			jobLogsFile := fmt.Sprintf("%s/%s", reportDir, jobLogsFilename(namespace, name))
+			if logsErr = os.WriteFile(jobLogsFile, []byte(logs), os.FileMode(0o644)); logsErr != nil {
+				return fmt.Errorf("failed to write job %s logs to file: %w", name, logsErr)
+			}
+			jobLogsFiles = append(jobLogsFiles, jobLogsFile)
 		}
-		return fmt.Errorf("%s/%s failed to complete (check %s for more info): %w", osdClusterReadyNamespace, osdClusterReadyName, jobLogsFile, err)
+		return fmt.Errorf("%s failed to complete (check %s for more info): %w", description, strings.Join(jobLogsFiles, ", "), err)
 	}
 
-	c.log.Info("Cluster job finished successfully!", jobNameLoggerKey, osdClusterReadyName)
+	c.log.Info("Cluster job finished successfully!", jobNameLoggerKey, options.jobName, jobNamespaceLoggerKey, namespace, jobSelectorLoggerKey, options.jobSelector)
 
 	return nil
 }
 
+// nodesHealthy returns true when the expected number of nodes exist and no more than the max are not ready
+func nodesHealthy(nodes []corev1.Node, computeNodes, maxNotReadyNodes int) bool {
+	if len(nodes) == 0 {
+		return false
+	}
+
+	notReady := 0
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+				notReady++
+			}
+		}
+	}
+
+	return notReady <= maxNotReadyNodes && len(nodes) == computeNodes
+}
+
 // HCPClusterHealthy waits for the cluster to be in a health "ready" state
-// by confirming nodes are available
-func (c *Client) HCPClusterHealthy(ctx context.Context, computeNodes int, timeout time.Duration) error {
+// by confirming nodes are available. WithMaxNotReadyNodes can be provided
+// to tolerate nodes that are not ready
+func (c *Client) HCPClusterHealthy(ctx context.Context, computeNodes int, timeout time.Duration, opts ...HealthCheckOption) error {
+	options := &healthCheckOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	c.log.Info("Waiting for hosted control plane cluster to healthy", timeoutLoggerKey, timeout.Round(time.Second).String())
 
 	err := wait.For(func(ctx context.Context) (bool, error) {
@@ -71,19 +244,7 @@ func (c *Client) HCPClusterHealthy(ctx context.Context, computeNodes int, timeou
 			return false, err
 		}
 
-		if len(nodes.Items) == 0 {
-			return false, nil
-		}
-
-		for _, node := range nodes.Items {
-			for _, condition := range node.Status.Conditions {
-				if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
-					return false, nil
-				}
-			}
-		}
-
-		return len(nodes.Items) == computeNodes, nil
+		return nodesHealthy(nodes.Items, computeNodes, options.maxNotReadyNodes), nil
 	}, wait.WithTimeout(timeout))
 	if err != nil {
 		return fmt.Errorf("hosted control plane cluster health check failed: %w", err)
@@ -93,3 +254,12 @@ func (c *Client) HCPClusterHealthy(ctx context.Context, computeNodes int, timeou
 
 	return nil
 }
+
+// jobLogsFilename returns the name of the file the jobs logs are written to, the
+// default job keeps its historical name so existing artifact paths still resolve
+func jobLogsFilename(namespace, name string) string {
+	if namespace == osdClusterReadyNamespace && name == osdClusterReadyName {
+		return fmt.Sprintf("%s.log", name)
+	}
+	return fmt.Sprintf("%s-%s.log", namespace, name)
+}
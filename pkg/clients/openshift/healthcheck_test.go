@@ -0,0 +1,99 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("health check job completion", func() {
+	newJob := func(name string, complete bool) batchv1.Job {
+		job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if complete {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+
+	// logMarker mimics a predicate requiring job success and a log marker
+	logMarker := func(logs map[string]string) JobCompletion {
+		return func(_ context.Context, job batchv1.Job) (bool, error) {
+			return jobComplete(job) && logs[job.GetName()] == "cluster ready", nil
+		}
+	}
+
+	It("should use the custom predicate", func(ctx context.Context) {
+		jobs := []batchv1.Job{newJob("a", true), newJob("b", true)}
+
+		complete, err := jobsComplete(ctx, jobs, logMarker(map[string]string{"a": "cluster ready"}))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(complete).Should(BeFalse())
+
+		complete, err = jobsComplete(ctx, jobs, logMarker(map[string]string{"a": "cluster ready", "b": "cluster ready"}))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(complete).Should(BeTrue())
+	})
+
+	It("should not be complete when the job has not succeeded", func(ctx context.Context) {
+		complete, err := jobsComplete(ctx, []batchv1.Job{newJob("a", false)}, logMarker(map[string]string{"a": "cluster ready"}))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(complete).Should(BeFalse())
+	})
+
+	It("should not be complete without jobs", func(ctx context.Context) {
+		complete, err := jobsComplete(ctx, nil, logMarker(nil))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(complete).Should(BeFalse())
+	})
+
+	It("should return the predicate error", func(ctx context.Context) {
+		_, err := jobsComplete(ctx, []batchv1.Job{newJob("a", true)}, func(context.Context, batchv1.Job) (bool, error) {
+			return false, errors.New("logs unavailable")
+		})
+		Expect(err).Should(MatchError("logs unavailable"))
+	})
+})
+
+var _ = Describe("hosted control plane nodes", func() {
+	newNodes := func(ready, notReady int) []corev1.Node {
+		var nodes []corev1.Node
+		for i := 0; i < ready+notReady; i++ {
+			status := corev1.ConditionTrue
+			if i >= ready {
+				status = corev1.ConditionFalse
+			}
+			nodes = append(nodes, corev1.Node{Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			}})
+		}
+		return nodes
+	}
+
+	DescribeTable("should be healthy within the not ready tolerance",
+		func(nodes []corev1.Node, computeNodes, maxNotReadyNodes int, healthy bool) {
+			Expect(nodesHealthy(nodes, computeNodes, maxNotReadyNodes)).Should(Equal(healthy))
+		},
+		Entry("all ready", newNodes(3, 0), 3, 0, true),
+		Entry("not ready without tolerance", newNodes(2, 1), 3, 0, false),
+		Entry("not ready within tolerance", newNodes(4, 2), 6, 2, true),
+		Entry("not ready beyond tolerance", newNodes(3, 3), 6, 2, false),
+		Entry("missing nodes", newNodes(2, 0), 3, 1, false),
+		Entry("no nodes", newNodes(0, 0), 0, 0, false),
+	)
+})
+
+var _ = Describe("health check job logs", func() {
+	DescribeTable("should name the logs file after the job",
+		func(namespace, name, expected string) {
+			Expect(jobLogsFilename(namespace, name)).Should(Equal(expected))
+		},
+		Entry("default job", "openshift-monitoring", "osd-cluster-ready", "osd-cluster-ready.log"),
+		Entry("other job", "openshift-monitoring", "other-ready", "openshift-monitoring-other-ready.log"),
+		Entry("other namespace", "custom", "osd-cluster-ready", "custom-osd-cluster-ready.log"),
+	)
+})
@@ -0,0 +1,47 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// infrastructureName is the name of the cluster scoped infrastructure config resource
+const infrastructureName = "cluster"
+
+// GetInfrastructure returns the clusters infrastructure config resource
+func (c Client) GetInfrastructure(ctx context.Context) (*configv1.Infrastructure, error) {
+	var infrastructure configv1.Infrastructure
+	if err := c.Get(ctx, infrastructureName, "", &infrastructure); err != nil {
+		return nil, fmt.Errorf("failed to get infrastructure %q: %w", infrastructureName, err)
+	}
+	return &infrastructure, nil
+}
+
+// GetInfrastructureName returns the infrastructure name used to prefix the clusters cloud resources
+func (c Client) GetInfrastructureName(ctx context.Context) (string, error) {
+	infrastructure, err := c.GetInfrastructure(ctx)
+	if err != nil {
+		return "", err
+	}
+	return infrastructure.Status.InfrastructureName, nil
+}
+
+// GetPlatformType returns the clusters underlying platform, e.g. AWS or GCP
+func (c Client) GetPlatformType(ctx context.Context) (configv1.PlatformType, error) {
+	infrastructure, err := c.GetInfrastructure(ctx)
+	if err != nil {
+		return "", err
+	}
+	return platformType(infrastructure), nil
+}
+
+// platformType returns the platform type from the platform status, falling back
+// to the deprecated platform field set by older clusters
+func platformType(infrastructure *configv1.Infrastructure) configv1.PlatformType {
+	if infrastructure.Status.PlatformStatus != nil && infrastructure.Status.PlatformStatus.Type != "" {
+		return infrastructure.Status.PlatformStatus.Type
+	}
+	return infrastructure.Status.Platform //nolint:staticcheck
+}
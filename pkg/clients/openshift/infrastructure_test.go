@@ -0,0 +1,26 @@
+package openshift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+var _ = Describe("infrastructure", func() {
+	DescribeTable("should return the platform type",
+		func(status configv1.InfrastructureStatus, expected configv1.PlatformType) {
+			Expect(platformType(&configv1.Infrastructure{Status: status})).Should(Equal(expected))
+		},
+		Entry("platform status", configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+		}, configv1.AWSPlatformType),
+		Entry("deprecated platform", configv1.InfrastructureStatus{
+			Platform: configv1.GCPPlatformType,
+		}, configv1.GCPPlatformType),
+		Entry("platform status preferred", configv1.InfrastructureStatus{
+			Platform:       configv1.NonePlatformType,
+			PlatformStatus: &configv1.PlatformStatus{Type: configv1.GCPPlatformType},
+		}, configv1.GCPPlatformType),
+		Entry("unset", configv1.InfrastructureStatus{}, configv1.PlatformType("")),
+	)
+})
@@ -2,15 +2,41 @@ package openshift
 
 import (
 	"context"
+	"fmt"
 
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	metadataConfigMap = "osd-cluster-metadata"
 	configNamespace   = "openshift-config"
+
+	installConfigConfigMap = "cluster-config-v1"
+	installConfigNamespace = "kube-system"
+
+	fipsMetadataKey    = "api_openshift_com_fips"
+	privateMetadataKey = "api_openshift_com_private"
 )
 
+// MetadataUnavailableError is returned when the cluster metadata needed to
+// answer a query could not be read, as opposed to the answer being false
+type MetadataUnavailableError struct {
+	Key string
+	Err error
+}
+
+// Error returns the formatted error message when MetadataUnavailableError is invoked
+func (m *MetadataUnavailableError) Error() string {
+	return fmt.Sprintf("cluster metadata %q unavailable: %v", m.Key, m.Err)
+}
+
+// Unwrap returns the underlying error
+func (m *MetadataUnavailableError) Unwrap() error {
+	return m.Err
+}
+
 // getOsdClusterMetadata returns osd-cluster-metadata configmap data array from openshift-config namespace
 // this contains metadata about the cluster
 func (c Client) getOsdClusterMetadata(ctx context.Context) (map[string]string, error) {
@@ -52,3 +78,69 @@ func (c Client) GetRegion(ctx context.Context) (string, error) {
 	}
 	return cmData["hive_openshift_io_cluster-region"], nil
 }
+
+// IsFIPS returns true when the cluster was installed with fips enabled. When the
+// metadata configmap does not record it, the install config is used instead
+func (c Client) IsFIPS(ctx context.Context) (bool, error) {
+	if cmData, err := c.getOsdClusterMetadata(ctx); err == nil {
+		if fips, ok := metadataBool(cmData, fipsMetadataKey); ok {
+			return fips, nil
+		}
+	}
+
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, installConfigConfigMap, installConfigNamespace, &cm); err != nil {
+		return false, &MetadataUnavailableError{Key: fipsMetadataKey, Err: err}
+	}
+
+	fips, err := installConfigFIPS(cm.Data["install-config"])
+	if err != nil {
+		return false, &MetadataUnavailableError{Key: fipsMetadataKey, Err: err}
+	}
+	return fips, nil
+}
+
+// IsPrivate returns true when the cluster is private. When the metadata
+// configmap does not record it, the cluster dns config is used instead
+func (c Client) IsPrivate(ctx context.Context) (bool, error) {
+	if cmData, err := c.getOsdClusterMetadata(ctx); err == nil {
+		if private, ok := metadataBool(cmData, privateMetadataKey); ok {
+			return private, nil
+		}
+	}
+
+	var dns configv1.DNS
+	if err := c.Get(ctx, "cluster", "", &dns); err != nil {
+		return false, &MetadataUnavailableError{Key: privateMetadataKey, Err: err}
+	}
+	return dnsPrivate(&dns), nil
+}
+
+// metadataBool returns the boolean value of the key and whether it was set
+func metadataBool(cmData map[string]string, key string) (bool, bool) {
+	value, ok := cmData[key]
+	if !ok || value == "" {
+		return false, false
+	}
+	return value == "true", true
+}
+
+// installConfigFIPS returns the fips setting of the install config
+func installConfigFIPS(installConfig string) (bool, error) {
+	if installConfig == "" {
+		return false, fmt.Errorf("%s configmap has no install-config", installConfigConfigMap)
+	}
+
+	var config struct {
+		FIPS bool `json:"fips"`
+	}
+	if err := yaml.Unmarshal([]byte(installConfig), &config); err != nil {
+		return false, fmt.Errorf("failed to parse install-config: %w", err)
+	}
+	return config.FIPS, nil
+}
+
+// dnsPrivate returns true when the cluster has no public dns zone
+func dnsPrivate(dns *configv1.DNS) bool {
+	return dns.Spec.PublicZone == nil
+}
@@ -0,0 +1,42 @@
+package openshift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+var _ = Describe("cluster metadata", func() {
+	DescribeTable("should read boolean metadata keys",
+		func(cmData map[string]string, expected, expectedOK bool) {
+			value, ok := metadataBool(cmData, fipsMetadataKey)
+			Expect(value).Should(Equal(expected))
+			Expect(ok).Should(Equal(expectedOK))
+		},
+		Entry("true", map[string]string{fipsMetadataKey: "true"}, true, true),
+		Entry("false", map[string]string{fipsMetadataKey: "false"}, false, true),
+		Entry("absent", map[string]string{}, false, false),
+		Entry("empty", map[string]string{fipsMetadataKey: ""}, false, false),
+	)
+
+	DescribeTable("should read fips from the install config",
+		func(installConfig string, expected bool) {
+			fips, err := installConfigFIPS(installConfig)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fips).Should(Equal(expected))
+		},
+		Entry("enabled", "apiVersion: v1\nbaseDomain: example.com\nfips: true\n", true),
+		Entry("disabled", "apiVersion: v1\nfips: false\n", false),
+		Entry("unset", "apiVersion: v1\n", false),
+	)
+
+	It("should fail when the install config is missing", func() {
+		_, err := installConfigFIPS("")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should be private without a public dns zone", func() {
+		Expect(dnsPrivate(&configv1.DNS{})).Should(BeTrue())
+		Expect(dnsPrivate(&configv1.DNS{Spec: configv1.DNSSpec{PublicZone: &configv1.DNSZone{ID: "Z123"}}})).Should(BeFalse())
+	})
+})
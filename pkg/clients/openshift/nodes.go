@@ -0,0 +1,93 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+)
+
+const (
+	workerNodesSelector = "node-role.kubernetes.io/worker,!node-role.kubernetes.io/infra"
+
+	// machinePoolLabel is set on classic cluster nodes to their machine pool id
+	machinePoolLabel = "hive.openshift.io/machine-pool"
+	// nodePoolLabel is set on hosted control plane cluster nodes to their node
+	// pool name, <cluster name>-<machine pool id> or the machine pool id
+	nodePoolLabel = "hypershift.openshift.io/nodePool"
+)
+
+// WaitForWorkerNodes waits for the cluster worker nodes to all be ready and
+// for their count to be within the min and max node count (inclusive). Nodes of
+// every machine pool are counted, use WaitForMachinePoolNodes on clusters with
+// several machine pools
+func (c *Client) WaitForWorkerNodes(ctx context.Context, minNodes, maxNodes int, timeout time.Duration) error {
+	return c.WaitForMachinePoolNodes(ctx, "", "", minNodes, maxNodes, timeout)
+}
+
+// WaitForMachinePoolNodes waits for the worker nodes of the machine pool to all
+// be ready and for their count to be within the min and max node count
+// (inclusive). Every worker node is counted when the machine pool id is empty.
+// The cluster name matches the node pools of hosted control plane clusters,
+// which are named <cluster name>-<machine pool id>
+func (c *Client) WaitForMachinePoolNodes(ctx context.Context, clusterName, machinePoolID string, minNodes, maxNodes int, timeout time.Duration) error {
+	c.log.Info("Waiting for worker nodes", "machine_pool_id", machinePoolID, "min_nodes", minNodes, "max_nodes", maxNodes,
+		timeoutLoggerKey, timeout.Round(time.Second).String())
+
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		var nodes corev1.NodeList
+		if err := c.List(ctx, &nodes, resources.WithLabelSelector(workerNodesSelector)); err != nil {
+			if isRetryableAPIError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return workerNodesSettled(nodes.Items, clusterName, machinePoolID, minNodes, maxNodes), nil
+	}, wait.WithTimeout(timeout), wait.WithInterval(30*time.Second), wait.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("worker nodes failed to reach a count between %d and %d: %w", minNodes, maxNodes, err)
+	}
+
+	return nil
+}
+
+// workerNodesSettled returns true when the nodes of the machine pool are all
+// ready and their count is within the min and max node count
+func workerNodesSettled(nodes []corev1.Node, clusterName, machinePoolID string, minNodes, maxNodes int) bool {
+	count := 0
+	for _, node := range nodes {
+		if machinePoolID != "" && !inMachinePool(node, clusterName, machinePoolID) {
+			continue
+		}
+		if !nodeReady(node) {
+			return false
+		}
+		count++
+	}
+
+	return count >= minNodes && count <= maxNodes
+}
+
+// inMachinePool returns true when the node belongs to the machine pool of the cluster
+func inMachinePool(node corev1.Node, clusterName, machinePoolID string) bool {
+	if node.Labels[machinePoolLabel] == machinePoolID {
+		return true
+	}
+
+	nodePool := node.Labels[nodePoolLabel]
+	return nodePool == machinePoolID || (clusterName != "" && nodePool == clusterName+"-"+machinePoolID)
+}
+
+// nodeReady returns true when the node reports a true ready condition
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package openshift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("worker nodes", func() {
+	node := func(labels map[string]string, conditions ...corev1.NodeCondition) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Status:     corev1.NodeStatus{Conditions: conditions},
+		}
+	}
+	ready := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}
+	notReady := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse}
+
+	DescribeTable("should settle once the machine pool nodes are ready",
+		func(nodes []corev1.Node, machinePoolID string, expected bool) {
+			Expect(workerNodesSettled(nodes, "test", machinePoolID, 2, 2)).Should(Equal(expected))
+		},
+		Entry("default machine pool", []corev1.Node{
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+		}, "worker", true),
+		Entry("nodes of other machine pools", []corev1.Node{
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "extra"}, ready),
+		}, "worker", true),
+		Entry("nodes of other machine pools counted without a machine pool id", []corev1.Node{
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "extra"}, ready),
+		}, "", false),
+		Entry("hosted control plane node pool", []corev1.Node{
+			node(map[string]string{nodePoolLabel: "test-workers"}, ready),
+			node(map[string]string{nodePoolLabel: "test-workers"}, ready),
+			node(map[string]string{nodePoolLabel: "test-extra"}, ready),
+		}, "workers", true),
+		Entry("hosted control plane node pool with the machine pool id as a suffix", []corev1.Node{
+			node(map[string]string{nodePoolLabel: "test-workers"}, ready),
+			node(map[string]string{nodePoolLabel: "test-workers"}, ready),
+			node(map[string]string{nodePoolLabel: "test-extra-workers"}, ready),
+		}, "workers", true),
+		Entry("a node that is not ready", []corev1.Node{
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "worker"}, notReady),
+		}, "worker", false),
+		Entry("a node without a ready condition", []corev1.Node{
+			node(map[string]string{machinePoolLabel: "worker"}, ready),
+			node(map[string]string{machinePoolLabel: "worker"}),
+		}, "worker", false),
+	)
+})
@@ -0,0 +1,108 @@
+package openshift
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+)
+
+const (
+	oauthRouteName      = "oauth-openshift"
+	oauthRouteNamespace = "openshift-authentication"
+	oauthWellKnownPath  = "/.well-known/oauth-authorization-server"
+)
+
+// WaitForOAuthReady waits for the cluster oauth route to be admitted and for
+// the oauth server well-known endpoint to respond, which is required for any
+// login based tests
+func (c *Client) WaitForOAuthReady(ctx context.Context, timeout time.Duration) error {
+	c.log.Info("Waiting for oauth server to be ready", timeoutLoggerKey, timeout.Round(time.Second).String())
+
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// only the reachability of the endpoint is verified, the ingress ca is not always trusted
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		},
+	}
+
+	getRoute := func(ctx context.Context) (*routev1.Route, error) {
+		var route routev1.Route
+		if err := c.Get(ctx, oauthRouteName, oauthRouteNamespace, &route); err != nil {
+			return nil, err
+		}
+		return &route, nil
+	}
+
+	checkEndpoint := func(ctx context.Context, host string) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s%s", host, oauthWellKnownPath), nil)
+		if err != nil {
+			return err
+		}
+		response, err := httpClient.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code %d", response.StatusCode)
+		}
+		return nil
+	}
+
+	if err := pollOAuthReady(ctx, getRoute, checkEndpoint, 10*time.Second, timeout); err != nil {
+		return fmt.Errorf("oauth server is not ready: %w", err)
+	}
+
+	c.log.Info("OAuth server is ready!")
+
+	return nil
+}
+
+// pollOAuthReady waits until the route returned is admitted and its host's endpoint check passes
+func pollOAuthReady(ctx context.Context, getRoute func(context.Context) (*routev1.Route, error), checkEndpoint func(context.Context, string) error, interval, timeout time.Duration) error {
+	var lastErr error
+
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		route, err := getRoute(ctx)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to get route %s/%s: %w", oauthRouteNamespace, oauthRouteName, err)
+			return false, nil
+		}
+
+		if !routeAdmitted(route) {
+			lastErr = fmt.Errorf("route %s/%s is not admitted", oauthRouteNamespace, oauthRouteName)
+			return false, nil
+		}
+
+		if err = checkEndpoint(ctx, route.Spec.Host); err != nil {
+			lastErr = fmt.Errorf("oauth well-known endpoint is not reachable: %w", err)
+			return false, nil
+		}
+
+		return true, nil
+	}, wait.WithImmediate(), wait.WithInterval(interval), wait.WithTimeout(timeout), wait.WithContext(ctx))
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+
+	return err
+}
+
+// routeAdmitted returns true when the route has been admitted by at least one router
+func routeAdmitted(route *routev1.Route) bool {
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type == routev1.RouteAdmitted && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
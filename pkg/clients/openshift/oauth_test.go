@@ -0,0 +1,59 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("oauth", func() {
+	pendingRoute := &routev1.Route{Spec: routev1.RouteSpec{Host: "oauth.apps.example.com"}}
+	admittedRoute := &routev1.Route{
+		Spec: routev1.RouteSpec{Host: "oauth.apps.example.com"},
+		Status: routev1.RouteStatus{
+			Ingress: []routev1.RouteIngress{
+				{
+					Conditions: []routev1.RouteIngressCondition{
+						{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		},
+	}
+
+	It("should wait for the route to be admitted", func(ctx context.Context) {
+		routes := []*routev1.Route{pendingRoute, pendingRoute, admittedRoute}
+		calls := 0
+		getRoute := func(context.Context) (*routev1.Route, error) {
+			route := routes[calls]
+			calls++
+			return route, nil
+		}
+		var checkedHost string
+		checkEndpoint := func(_ context.Context, host string) error {
+			checkedHost = host
+			return nil
+		}
+
+		Expect(pollOAuthReady(ctx, getRoute, checkEndpoint, 10*time.Millisecond, time.Second)).Should(Succeed())
+		Expect(calls).Should(Equal(3))
+		Expect(checkedHost).Should(Equal("oauth.apps.example.com"))
+	})
+
+	It("should fail when the endpoint never responds", func(ctx context.Context) {
+		getRoute := func(context.Context) (*routev1.Route, error) {
+			return admittedRoute, nil
+		}
+		checkEndpoint := func(context.Context, string) error {
+			return errors.New("connection refused")
+		}
+
+		err := pollOAuthReady(ctx, getRoute, checkEndpoint, 10*time.Millisecond, 50*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("connection refused")))
+	})
+})
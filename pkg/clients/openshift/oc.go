@@ -0,0 +1,80 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/openshift/osde2e-common/internal/cmd"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ocBinary is the oc cli looked up on the path
+const ocBinary = "oc"
+
+// RunOC runs the oc cli with the arguments against the clients cluster, the
+// clients config is written to a temporary kubeconfig for the command
+//
+//	stdout, _, err := client.RunOC(ctx, "adm", "top", "nodes")
+func (c *Client) RunOC(ctx context.Context, args ...string) (string, string, error) {
+	dir, err := os.MkdirTemp("", "osde2e-oc-")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err = clientcmd.WriteToFile(kubeconfigFromRestConfig(c.GetConfig()), kubeconfig); err != nil {
+		return "", "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	command := exec.CommandContext(ctx, ocBinary, args...)
+	command.Env = append(os.Environ(), "KUBECONFIG="+kubeconfig)
+
+	stdout, stderr, err := cmd.Run(command)
+	if err != nil {
+		return fmt.Sprint(stdout), fmt.Sprint(stderr), fmt.Errorf("oc %v failed: %w, stderr: %v", args, err, stderr)
+	}
+
+	return fmt.Sprint(stdout), fmt.Sprint(stderr), nil
+}
+
+// kubeconfigFromRestConfig returns a kubeconfig authenticating the same way as the rest config
+func kubeconfigFromRestConfig(cfg *rest.Config) clientcmdapi.Config {
+	const name = "osde2e"
+
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = cfg.Host
+	cluster.CertificateAuthority = cfg.CAFile
+	cluster.CertificateAuthorityData = cfg.CAData
+	cluster.InsecureSkipTLSVerify = cfg.Insecure
+	cluster.TLSServerName = cfg.ServerName
+
+	authInfo := clientcmdapi.NewAuthInfo()
+	authInfo.Token = cfg.BearerToken
+	authInfo.TokenFile = cfg.BearerTokenFile
+	authInfo.ClientCertificate = cfg.CertFile
+	authInfo.ClientCertificateData = cfg.CertData
+	authInfo.ClientKey = cfg.KeyFile
+	authInfo.ClientKeyData = cfg.KeyData
+	authInfo.Username = cfg.Username
+	authInfo.Password = cfg.Password
+	authInfo.Impersonate = cfg.Impersonate.UserName
+	authInfo.ImpersonateGroups = cfg.Impersonate.Groups
+
+	kubeContext := clientcmdapi.NewContext()
+	kubeContext.Cluster = name
+	kubeContext.AuthInfo = name
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = cluster
+	kubeconfig.AuthInfos[name] = authInfo
+	kubeconfig.Contexts[name] = kubeContext
+	kubeconfig.CurrentContext = name
+
+	return *kubeconfig
+}
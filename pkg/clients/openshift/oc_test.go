@@ -0,0 +1,64 @@
+package openshift
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeOC prints its arguments and the server of the kubeconfig it was given
+const fakeOC = `#!/bin/sh
+if [ "$1" = "fail" ]; then
+  echo "error: unknown command" >&2
+  exit 1
+fi
+echo "$@"
+grep server "$KUBECONFIG"
+echo "kubeconfig read" >&2
+`
+
+var _ = Describe("oc", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "oc"), []byte(fakeOC), 0o755)).Should(Succeed())
+		GinkgoT().Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		kubeconfig := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(kubeconfig, []byte(multiContextKubeconfig), 0o600)).Should(Succeed())
+
+		var err error
+		client, err = NewFromKubeconfigContext(kubeconfig, "second-viewer", logr.Discard())
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should run oc against the clients cluster", func(ctx context.Context) {
+		stdout, stderr, err := client.RunOC(ctx, "adm", "top", "nodes")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stdout).Should(ContainSubstring("adm top nodes"))
+		Expect(stdout).Should(ContainSubstring("https://api.second.example.com:6443"))
+		Expect(stderr).Should(ContainSubstring("kubeconfig read"))
+	})
+
+	It("should return the stderr when oc fails", func(ctx context.Context) {
+		_, stderr, err := client.RunOC(ctx, "fail")
+		Expect(err).Should(HaveOccurred())
+		Expect(stderr).Should(ContainSubstring("unknown command"))
+	})
+
+	It("should carry over the credentials and impersonation", func() {
+		impersonated, err := client.Impersonate("test-user")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		kubeconfig := kubeconfigFromRestConfig(impersonated.GetConfig())
+		authInfo := kubeconfig.AuthInfos[kubeconfig.Contexts[kubeconfig.CurrentContext].AuthInfo]
+		Expect(authInfo.Token).Should(Equal("def456"))
+		Expect(authInfo.Impersonate).Should(Equal("test-user"))
+		Expect(authInfo.ImpersonateGroups).Should(ContainElement("system:authenticated"))
+	})
+})
@@ -0,0 +1,27 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	quotav1 "github.com/openshift/api/quota/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetResourceQuota returns the resource quota in the namespace
+func (c *Client) GetResourceQuota(ctx context.Context, namespace, name string) (*corev1.ResourceQuota, error) {
+	quota := new(corev1.ResourceQuota)
+	if err := c.Get(ctx, name, namespace, quota); err != nil {
+		return nil, fmt.Errorf("failed to get resource quota %s/%s: %w", namespace, name, err)
+	}
+	return quota, nil
+}
+
+// ListClusterResourceQuotas returns the cluster resource quotas spanning multiple namespaces
+func (c *Client) ListClusterResourceQuotas(ctx context.Context) ([]quotav1.ClusterResourceQuota, error) {
+	quotas := new(quotav1.ClusterResourceQuotaList)
+	if err := c.List(ctx, quotas); err != nil {
+		return nil, fmt.Errorf("failed to list cluster resource quotas: %w", err)
+	}
+	return quotas.Items, nil
+}
@@ -0,0 +1,17 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	securityv1 "github.com/openshift/api/security/v1"
+)
+
+// ListSCCs returns the security context constraints of the cluster
+func (c *Client) ListSCCs(ctx context.Context) ([]securityv1.SecurityContextConstraints, error) {
+	sccs := new(securityv1.SecurityContextConstraintsList)
+	if err := c.List(ctx, sccs); err != nil {
+		return nil, fmt.Errorf("failed to list security context constraints: %w", err)
+	}
+	return sccs.Items, nil
+}
@@ -0,0 +1,53 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	consoleRouteName      = "console"
+	consoleRouteNamespace = "openshift-console"
+)
+
+var (
+	// ErrConsoleNotFound is returned when the cluster has no console route (e.g. console disabled)
+	ErrConsoleNotFound = errors.New("cluster console route not found")
+	// ErrAPIURLNotFound is returned when the client config has no host defined
+	ErrAPIURLNotFound = errors.New("cluster api url not found")
+)
+
+// GetConsoleURL returns the web console url of the cluster
+func (c *Client) GetConsoleURL(ctx context.Context) (string, error) {
+	var route routev1.Route
+	if err := c.Get(ctx, consoleRouteName, consoleRouteNamespace, &route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: %s/%s", ErrConsoleNotFound, consoleRouteNamespace, consoleRouteName)
+		}
+		return "", fmt.Errorf("failed to get route %s/%s: %w", consoleRouteNamespace, consoleRouteName, err)
+	}
+
+	if route.Spec.Host == "" {
+		return "", fmt.Errorf("%w: %s/%s has no host", ErrConsoleNotFound, consoleRouteNamespace, consoleRouteName)
+	}
+
+	scheme := "https"
+	if route.Spec.TLS == nil {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, route.Spec.Host), nil
+}
+
+// GetAPIURL returns the api server url of the cluster the client is configured for
+func (c *Client) GetAPIURL() (string, error) {
+	config := c.GetConfig()
+	if config == nil || config.Host == "" {
+		return "", ErrAPIURLNotFound
+	}
+	return config.Host, nil
+}
@@ -0,0 +1,61 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/common/model"
+)
+
+// firingAlertsQuery selects every alert currently firing
+const firingAlertsQuery = `ALERTS{alertstate="firing"}`
+
+// Alert represents a firing prometheus alert
+type Alert struct {
+	Name     string
+	Severity string
+	Labels   map[string]string
+}
+
+// Alerts returns the alerts currently firing
+func (c *Client) Alerts(ctx context.Context) ([]Alert, error) {
+	vector, err := c.InstantQuery(ctx, firingAlertsQuery)
+	if err != nil {
+		return nil, err
+	}
+	return alertsFromVector(vector), nil
+}
+
+// FiringAlertsBySeverity returns the alerts currently firing with the severity
+func (c *Client) FiringAlertsBySeverity(ctx context.Context, severity string) ([]Alert, error) {
+	alerts, err := c.Alerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []Alert
+	for _, alert := range alerts {
+		if alert.Severity == severity {
+			filtered = append(filtered, alert)
+		}
+	}
+
+	return filtered, nil
+}
+
+// alertsFromVector converts the ALERTS series into alerts
+func alertsFromVector(vector model.Vector) []Alert {
+	alerts := make([]Alert, 0, len(vector))
+	for _, sample := range vector {
+		labels := make(map[string]string, len(sample.Metric))
+		for name, value := range sample.Metric {
+			labels[string(name)] = string(value)
+		}
+
+		alerts = append(alerts, Alert{
+			Name:     labels[model.AlertNameLabel],
+			Severity: labels["severity"],
+			Labels:   labels,
+		})
+	}
+	return alerts
+}
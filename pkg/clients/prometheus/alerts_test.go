@@ -0,0 +1,44 @@
+package prometheus
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/common/model"
+)
+
+var _ = Describe("alerts", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = &Client{prometheus: &fakeAPI{query: func(_ context.Context, query string) (model.Value, error) {
+			Expect(query).Should(Equal(firingAlertsQuery))
+			return model.Vector{
+				{Metric: model.Metric{"alertname": "Watchdog", "severity": "none", "alertstate": "firing"}},
+				{Metric: model.Metric{"alertname": "KubePodCrashLooping", "severity": "warning", "namespace": "test"}},
+				{Metric: model.Metric{"alertname": "etcdMembersDown", "severity": "critical"}},
+			}, nil
+		}}}
+	})
+
+	It("should return the firing alerts", func() {
+		alerts, err := client.Alerts(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(alerts).Should(HaveLen(3))
+		Expect(alerts[1].Name).Should(Equal("KubePodCrashLooping"))
+		Expect(alerts[1].Severity).Should(Equal("warning"))
+		Expect(alerts[1].Labels).Should(HaveKeyWithValue("namespace", "test"))
+	})
+
+	It("should filter the firing alerts by severity", func() {
+		alerts, err := client.FiringAlertsBySeverity(context.Background(), "critical")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(alerts).Should(HaveLen(1))
+		Expect(alerts[0].Name).Should(Equal("etcdMembersDown"))
+
+		alerts, err = client.FiringAlertsBySeverity(context.Background(), "info")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(alerts).Should(BeEmpty())
+	})
+})
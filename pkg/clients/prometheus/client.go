@@ -4,21 +4,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	routev1client "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/library-go/test/library/metrics"
 	"github.com/openshift/osde2e-common/pkg/clients/openshift"
+	prometheusapi "github.com/prometheus/client_golang/api"
 	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/e2e-framework/klient/wait"
 )
 
+// readyInterval is the interval between queries while waiting for prometheus to be ready
+const readyInterval = 10 * time.Second
+
+// defaultQueryTimeout is used when the clients QueryTimeout is unset
+const defaultQueryTimeout = 30 * time.Second
+
+// ErrQueryTimeout is returned when a query does not complete within the clients QueryTimeout
+var ErrQueryTimeout = errors.New("prometheus query timed out")
+
 type Client struct {
 	prometheus prometheusv1.API
+
+	// QueryTimeout bounds each query, defaults to 30 seconds when unset
+	QueryTimeout time.Duration
 }
 
-// TODO: should we use thanos querier instead?
+// New returns a client querying the cluster monitoring stack. The library-go
+// client it wraps connects through the thanos-querier route in
+// openshift-monitoring, so results span prometheus pod restarts
 func New(ctx context.Context, client *openshift.Client) (*Client, error) {
 	cfg := client.GetConfig()
 	kubeClient, err := kubernetes.NewForConfig(cfg)
@@ -39,14 +58,66 @@ func New(ctx context.Context, client *openshift.Client) (*Client, error) {
 	return &Client{prometheus: prometheus}, nil
 }
 
+// NewThanos returns a client querying the thanos-querier route in
+// openshift-monitoring. It shares the query methods of the client returned by
+// New and is preferred for long retention queries
+func NewThanos(ctx context.Context, client *openshift.Client) (*Client, error) {
+	return New(ctx, client)
+}
+
+// NewFromTokenAndRoute returns a client querying the prometheus or thanos
+// route using the bearer token, for callers without a kubeconfig. The route
+// defaults to https when it has no scheme and is verified with the system roots
+func NewFromTokenAndRoute(ctx context.Context, routeURL, token string) (*Client, error) {
+	if routeURL == "" || token == "" {
+		return nil, errors.New("route url and token are required")
+	}
+
+	if !strings.Contains(routeURL, "://") {
+		routeURL = "https://" + routeURL
+	}
+
+	client, err := prometheusapi.NewClient(prometheusapi.Config{
+		Address: routeURL,
+		RoundTripper: transport.NewBearerAuthRoundTripper(token, &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSHandshakeTimeout: 10 * time.Second,
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+
+	return &Client{prometheus: prometheusv1.NewAPI(client)}, nil
+}
+
 func (c *Client) GetClient() prometheusv1.API {
 	return c.prometheus
 }
 
+// queryTimeout returns the timeout applied to each query
+func (c *Client) queryTimeout() time.Duration {
+	if c.QueryTimeout <= 0 {
+		return defaultQueryTimeout
+	}
+	return c.QueryTimeout
+}
+
+// queryError wraps the query error, identifying queries that exceeded the query timeout
+func (c *Client) queryError(queryCtx context.Context, err error) error {
+	if errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %v", ErrQueryTimeout, c.queryTimeout(), err)
+	}
+	return fmt.Errorf("query failed: %w", err)
+}
+
 func (c *Client) InstantQuery(ctx context.Context, query string) (model.Vector, error) {
-	result, warnings, err := c.prometheus.Query(ctx, query, time.Now())
+	queryCtx, cancel := context.WithTimeout(ctx, c.queryTimeout())
+	defer cancel()
+
+	result, warnings, err := c.prometheus.Query(queryCtx, query, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("query failed: %w", err)
+		return nil, c.queryError(queryCtx, err)
 	}
 
 	// TODO: do something with these
@@ -59,3 +130,23 @@ func (c *Client) InstantQuery(ctx context.Context, query string) (model.Vector,
 
 	return vector, nil
 }
+
+// WaitForReady queries prometheus until it responds successfully or the timeout elapses
+func (c *Client) WaitForReady(ctx context.Context, timeout time.Duration) error {
+	return c.waitForReady(ctx, readyInterval, timeout)
+}
+
+// waitForReady issues a trivial query at the interval until it succeeds
+func (c *Client) waitForReady(ctx context.Context, interval, timeout time.Duration) error {
+	var lastErr error
+
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		_, lastErr = c.InstantQuery(ctx, "up")
+		return lastErr == nil, nil
+	}, wait.WithImmediate(), wait.WithInterval(interval), wait.WithTimeout(timeout), wait.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("prometheus never became ready (last error %v): %w", lastErr, err)
+	}
+
+	return nil
+}
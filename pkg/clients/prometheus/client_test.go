@@ -0,0 +1,128 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// fakeAPI implements the prometheus api queries used by the client
+type fakeAPI struct {
+	prometheusv1.API
+	query func(ctx context.Context, query string) (model.Value, error)
+}
+
+func (f *fakeAPI) Query(ctx context.Context, query string, _ time.Time, _ ...prometheusv1.Option) (model.Value, prometheusv1.Warnings, error) {
+	value, err := f.query(ctx, query)
+	return value, nil, err
+}
+
+var _ = Describe("instant query", func() {
+	It("should apply the default query timeout", func() {
+		client := &Client{prometheus: &fakeAPI{query: func(ctx context.Context, _ string) (model.Value, error) {
+			deadline, ok := ctx.Deadline()
+			Expect(ok).Should(BeTrue())
+			Expect(time.Until(deadline)).Should(BeNumerically("~", defaultQueryTimeout, time.Second))
+			return model.Vector{}, nil
+		}}}
+
+		_, err := client.InstantQuery(context.Background(), "up")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should return a timeout error when the query exceeds the query timeout", func() {
+		client := &Client{
+			QueryTimeout: 10 * time.Millisecond,
+			prometheus: &fakeAPI{query: func(ctx context.Context, _ string) (model.Value, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}},
+		}
+
+		_, err := client.InstantQuery(context.Background(), "up")
+		Expect(err).Should(MatchError(ErrQueryTimeout))
+	})
+
+	It("should not report query errors as timeouts", func() {
+		client := &Client{prometheus: &fakeAPI{query: func(context.Context, string) (model.Value, error) {
+			return nil, errors.New("bad_data: parse error")
+		}}}
+
+		_, err := client.InstantQuery(context.Background(), "up{")
+		Expect(err).Should(HaveOccurred())
+		Expect(errors.Is(err, ErrQueryTimeout)).Should(BeFalse())
+	})
+})
+
+var _ = Describe("wait for ready", func() {
+	It("should query until prometheus responds", func(ctx context.Context) {
+		calls := 0
+		client := &Client{prometheus: &fakeAPI{query: func(_ context.Context, query string) (model.Value, error) {
+			Expect(query).Should(Equal("up"))
+			calls++
+			if calls < 3 {
+				return nil, errors.New("service unavailable")
+			}
+			return model.Vector{}, nil
+		}}}
+
+		Expect(client.waitForReady(ctx, time.Millisecond, time.Second)).Should(Succeed())
+		Expect(calls).Should(Equal(3))
+	})
+
+	It("should fail when prometheus never responds", func(ctx context.Context) {
+		client := &Client{prometheus: &fakeAPI{query: func(context.Context, string) (model.Value, error) {
+			return nil, errors.New("service unavailable")
+		}}}
+
+		err := client.waitForReady(ctx, time.Millisecond, 20*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("service unavailable")))
+	})
+})
+
+var _ = Describe("new from token and route", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	It("should send the bearer token with queries", func(ctx context.Context) {
+		client, err := NewFromTokenAndRoute(ctx, server.URL, "secret")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := client.InstantQuery(ctx, "up")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(BeEmpty())
+	})
+
+	It("should fail queries with an invalid token", func(ctx context.Context) {
+		client, err := NewFromTokenAndRoute(ctx, server.URL, "wrong")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = client.InstantQuery(ctx, "up")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should require the route and token", func(ctx context.Context) {
+		_, err := NewFromTokenAndRoute(ctx, "", "secret")
+		Expect(err).Should(HaveOccurred())
+		_, err = NewFromTokenAndRoute(ctx, server.URL, "")
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,60 @@
+// Package concurrency provides bounded fan out for bulk operations
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultLimit is the number of operations run at once when no limit is provided,
+// kept low to stay within OCM and cloud provider rate limits
+const DefaultLimit = 4
+
+// ForEach invokes fn for each item running at most limit invocations at once.
+// Every item is attempted even when others fail, items not yet started when
+// the context is done are skipped. The errors of all failed invocations are
+// joined and returned
+func ForEach[T any](ctx context.Context, limit int, items []T, fn func(context.Context, T) error) error {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	semaphore := make(chan struct{}, limit)
+
+	for _, item := range items {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		wg.Add(1)
+		go func(item T) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			if err := fn(ctx, item); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(item)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
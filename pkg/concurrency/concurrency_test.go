@@ -0,0 +1,79 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingRunner records the number of operations running at once
+type countingRunner struct {
+	mu      sync.Mutex
+	running int
+	max     int
+	calls   int
+}
+
+func (c *countingRunner) run(ctx context.Context, item int) error {
+	c.mu.Lock()
+	c.running++
+	c.calls++
+	if c.running > c.max {
+		c.max = c.running
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	c.running--
+	c.mu.Unlock()
+
+	if item%5 == 0 {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+var _ = Describe("for each", func() {
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i + 1
+	}
+
+	DescribeTable("should not run more than the limit at once",
+		func(limit, expectedMax int) {
+			runner := &countingRunner{}
+			err := ForEach(context.Background(), limit, items, runner.run)
+			Expect(err).Should(HaveOccurred())
+			Expect(runner.calls).Should(Equal(len(items)))
+			Expect(runner.max).Should(BeNumerically("<=", expectedMax))
+			Expect(runner.max).Should(BeNumerically(">", 1))
+		},
+		Entry("explicit limit", 3, 3),
+		Entry("default limit", 0, DefaultLimit),
+	)
+
+	It("should join the errors of every failed operation", func() {
+		err := ForEach(context.Background(), 2, items, (&countingRunner{}).run)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.(interface{ Unwrap() []error }).Unwrap()).Should(HaveLen(4))
+	})
+
+	It("should skip the remaining operations when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		runner := &countingRunner{}
+		err := ForEach(ctx, 1, items, func(ctx context.Context, item int) error {
+			if item == 2 {
+				cancel()
+			}
+			return runner.run(ctx, 1)
+		})
+		Expect(err).Should(MatchError(context.Canceled))
+		Expect(runner.calls).Should(BeNumerically("<", len(items)))
+	})
+})
@@ -0,0 +1,22 @@
+package assertions
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+// eventually returns the gomega async assertion polling actual. The optional
+// intervals are the timeout followed by the polling interval, as with
+// gomega.Eventually, zero values keep gomegas defaults
+func eventually(ctx context.Context, actual any, intervals []time.Duration) gomega.AsyncAssertion {
+	assertion := gomega.Eventually(ctx, actual)
+	if len(intervals) > 0 && intervals[0] > 0 {
+		assertion = assertion.WithTimeout(intervals[0])
+	}
+	if len(intervals) > 1 && intervals[1] > 0 {
+		assertion = assertion.WithPolling(intervals[1])
+	}
+	return assertion
+}
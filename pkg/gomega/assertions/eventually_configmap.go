@@ -2,6 +2,7 @@ package assertions
 
 import (
 	"context"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/openshift/osde2e-common/pkg/clients/openshift"
@@ -12,10 +13,14 @@ import (
 // standard or custom gomega matchers
 //
 //	EventuallyConfigMap(ctx, client, configMapName, namespace).ShouldNot(BeNil()), "config map %s should exist", configMapName)
-func EventuallyConfigMap(ctx context.Context, client *openshift.Client, name, namespace string) gomega.AsyncAssertion {
-	return gomega.Eventually(ctx, func(ctx context.Context) (*corev1.ConfigMap, error) {
+//
+// The optional intervals are the timeout followed by the polling interval
+//
+//	EventuallyConfigMap(ctx, client, configMapName, namespace, 5*time.Minute, 10*time.Second).ShouldNot(BeNil())
+func EventuallyConfigMap(ctx context.Context, client *openshift.Client, name, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (*corev1.ConfigMap, error) {
 		var configMap corev1.ConfigMap
 		err := client.Get(ctx, name, namespace, &configMap)
 		return &configMap, err
-	})
+	}, intervals)
 }
@@ -0,0 +1,91 @@
+package assertions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/openshift/osde2e-common/pkg/clients/openshift"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const csvPhaseSucceeded = "Succeeded"
+
+// csvResource is the cluster service version resource managed by olm
+var csvResource = schema.GroupVersionResource{
+	Group:    "operators.coreos.com",
+	Version:  "v1alpha1",
+	Resource: "clusterserviceversions",
+}
+
+// EventuallyCsv is a gomega async assertion polling for the cluster service
+// version with the display name to succeed
+//
+//	EventuallyCsv(ctx, client, "Managed Upgrade Operator", namespace).Should(BeTrue())
+func EventuallyCsv(ctx context.Context, client *openshift.Client, specDisplayName, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return EventuallyCsvPhase(ctx, client, specDisplayName, namespace, csvPhaseSucceeded, intervals...)
+}
+
+// EventuallyCsvPhase is a gomega async assertion polling for the cluster
+// service version with the display name to reach the phase
+//
+//	EventuallyCsvPhase(ctx, client, "Managed Upgrade Operator", namespace, "Failed").Should(BeTrue())
+func EventuallyCsvPhase(ctx context.Context, client *openshift.Client, specDisplayName, namespace, phase string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (bool, error) {
+		dynamicClient, err := dynamic.NewForConfig(client.GetConfig())
+		if err != nil {
+			return false, fmt.Errorf("failed creating the dynamic client: %w", err)
+		}
+		csvPhase, err := getCsvPhase(ctx, dynamicClient, specDisplayName, namespace)
+		return csvPhase == phase, err
+	}, intervals)
+}
+
+// EventuallyCSVObject is a gomega async assertion returning the cluster
+// service version that can be used with the standard or custom gomega matchers
+//
+//	EventuallyCSVObject(ctx, client, "managed-upgrade-operator.v0.1.0", namespace).Should(HaveField("Object", HaveKey("spec")))
+func EventuallyCSVObject(ctx context.Context, client *openshift.Client, name, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		dynamicClient, err := dynamic.NewForConfig(client.GetConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed creating the dynamic client: %w", err)
+		}
+		return getCSV(ctx, dynamicClient, name, namespace)
+	}, intervals)
+}
+
+// getCSV returns the cluster service version
+func getCSV(ctx context.Context, dynamicClient dynamic.Interface, name, namespace string) (*unstructured.Unstructured, error) {
+	csv, err := dynamicClient.Resource(csvResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSV %s/%s: %w", namespace, name, err)
+	}
+	return csv, nil
+}
+
+// getCsvPhase returns the phase of the cluster service version with the display name, empty when none exists
+func getCsvPhase(ctx context.Context, dynamicClient dynamic.Interface, specDisplayName, namespace string) (string, error) {
+	csvs, err := dynamicClient.Resource(csvResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list CSVs in %s: %w", namespace, err)
+	}
+
+	for _, csv := range csvs.Items {
+		displayName, _, err := unstructured.NestedString(csv.Object, "spec", "displayName")
+		if err != nil || displayName != specDisplayName {
+			continue
+		}
+		phase, _, err := unstructured.NestedString(csv.Object, "status", "phase")
+		if err != nil {
+			return "", fmt.Errorf("failed to get CSV %s/%s phase: %w", namespace, csv.GetName(), err)
+		}
+		return phase, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,59 @@
+package assertions
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newCSV returns a cluster service version with the display name in the phase
+func newCSV(name, namespace, displayName, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "ClusterServiceVersion",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec":       map[string]any{"displayName": displayName},
+		"status":     map[string]any{"phase": phase},
+	}}
+}
+
+var _ = Describe("csv phase", func() {
+	var dynamicClient *dynamicfake.FakeDynamicClient
+
+	BeforeEach(func() {
+		dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{csvResource: "ClusterServiceVersionList"},
+			newCSV("muo.v0.1.0", "openshift-managed-upgrade-operator", "Managed Upgrade Operator", "Installing"),
+			newCSV("rmo.v0.1.0", "openshift-route-monitor-operator", "Route Monitor Operator", "Failed"),
+		)
+	})
+
+	DescribeTable("should return the phase of the csv with the display name",
+		func(ctx context.Context, displayName, namespace, expected string) {
+			phase, err := getCsvPhase(ctx, dynamicClient, displayName, namespace)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(expected))
+		},
+		Entry("installing", "Managed Upgrade Operator", "openshift-managed-upgrade-operator", "Installing"),
+		Entry("failed", "Route Monitor Operator", "openshift-route-monitor-operator", "Failed"),
+		Entry("other namespace", "Route Monitor Operator", "openshift-managed-upgrade-operator", ""),
+		Entry("missing", "Splunk Forwarder Operator", "openshift-managed-upgrade-operator", ""),
+	)
+
+	It("should return the csv object", func(ctx context.Context) {
+		csv, err := getCSV(ctx, dynamicClient, "muo.v0.1.0", "openshift-managed-upgrade-operator")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(csv.GetName()).Should(Equal("muo.v0.1.0"))
+		Expect(csv.Object).Should(HaveKeyWithValue("spec", HaveKeyWithValue("displayName", "Managed Upgrade Operator")))
+	})
+
+	It("should fail when the csv does not exist", func(ctx context.Context) {
+		_, err := getCSV(ctx, dynamicClient, "missing.v0.1.0", "openshift-managed-upgrade-operator")
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -2,6 +2,7 @@ package assertions
 
 import (
 	"context"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/openshift/osde2e-common/pkg/clients/openshift"
@@ -12,10 +13,10 @@ import (
 // standard or custom gomega matchers
 //
 //	EventuallyDeployment(ctx, client, "test", "default").Should(BeAvailable())
-func EventuallyDeployment(ctx context.Context, client *openshift.Client, name, namespace string) gomega.AsyncAssertion {
-	return gomega.Eventually(ctx, func(ctx context.Context) (*appsv1.Deployment, error) {
+func EventuallyDeployment(ctx context.Context, client *openshift.Client, name, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (*appsv1.Deployment, error) {
 		var deployment appsv1.Deployment
 		err := client.Get(ctx, name, namespace, &deployment)
 		return &deployment, err
-	})
+	}, intervals)
 }
@@ -0,0 +1,24 @@
+package assertions
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/openshift/osde2e-common/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventuallyPod is a gomega async assertion that can be used with the
+// standard or custom gomega matchers
+//
+//	EventuallyPod(ctx, client, podName, namespace).Should(BeInPhase(corev1.PodRunning), "pod %s should be running", podName)
+//
+// The optional intervals are the timeout followed by the polling interval
+func EventuallyPod(ctx context.Context, client *openshift.Client, name, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (*corev1.Pod, error) {
+		var pod corev1.Pod
+		err := client.Get(ctx, name, namespace, &pod)
+		return &pod, err
+	}, intervals)
+}
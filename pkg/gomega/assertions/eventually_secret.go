@@ -0,0 +1,22 @@
+package assertions
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/openshift/osde2e-common/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventuallySecret is a gomega async assertion that can be used with the
+// standard or custom gomega matchers
+//
+//	EventuallySecret(ctx, client, secretName, namespace).Should(HaveField("Data", HaveKey("token")), "secret %s should contain a token", secretName)
+func EventuallySecret(ctx context.Context, client *openshift.Client, name, namespace string, intervals ...time.Duration) gomega.AsyncAssertion {
+	return eventually(ctx, func(ctx context.Context) (*corev1.Secret, error) {
+		var secret corev1.Secret
+		err := client.Get(ctx, name, namespace, &secret)
+		return &secret, err
+	}, intervals)
+}
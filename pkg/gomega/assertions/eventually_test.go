@@ -0,0 +1,32 @@
+package assertions
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("eventually intervals", func() {
+	It("should poll at the polling interval", func(ctx context.Context) {
+		calls := 0
+		eventually(ctx, func() int {
+			calls++
+			return calls
+		}, []time.Duration{time.Second, time.Millisecond}).Should(BeNumerically(">=", 5))
+	})
+
+	It("should fail once the timeout elapses", func(ctx context.Context) {
+		start := time.Now()
+		failures := InterceptGomegaFailures(func() {
+			eventually(ctx, func() bool { return false }, []time.Duration{20 * time.Millisecond, time.Millisecond}).Should(BeTrue())
+		})
+		Expect(failures).Should(HaveLen(1))
+		Expect(time.Since(start)).Should(BeNumerically("<", time.Second))
+	})
+
+	It("should keep the gomega defaults for zero intervals", func(ctx context.Context) {
+		eventually(ctx, func() bool { return true }, []time.Duration{0, 0}).Should(BeTrue())
+	})
+})
@@ -0,0 +1,22 @@
+package matchers
+
+import (
+	"errors"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+	securityv1 "github.com/openshift/api/security/v1"
+)
+
+// AllowPrivilegedContainers is a custom gomega matcher to match on a security
+// context constraint permitting privileged containers
+//
+//	Expect(scc).Should(AllowPrivilegedContainers())
+func AllowPrivilegedContainers() types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(scc *securityv1.SecurityContextConstraints) (bool, error) {
+		if scc == nil {
+			return false, errors.New("security context constraint is nil")
+		}
+		return scc.AllowPrivilegedContainer, nil
+	}).WithTemplate("Expected security context constraint {{.Actual.Name}}\n{{.To}} allow privileged containers")
+}
@@ -0,0 +1,32 @@
+package matchers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	securityv1 "github.com/openshift/api/security/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("security context constraint", func() {
+	It("should allow privileged containers", func() {
+		scc := &securityv1.SecurityContextConstraints{
+			ObjectMeta:               metav1.ObjectMeta{Name: "privileged"},
+			AllowPrivilegedContainer: true,
+		}
+		Expect(scc).Should(AllowPrivilegedContainers())
+	})
+
+	It("should not allow privileged containers", func() {
+		scc := &securityv1.SecurityContextConstraints{
+			ObjectMeta: metav1.ObjectMeta{Name: "restricted-v2"},
+		}
+		Expect(scc).ShouldNot(AllowPrivilegedContainers())
+		Expect(AllowPrivilegedContainers().FailureMessage(scc)).Should(ContainSubstring("restricted-v2"))
+	})
+
+	It("should error on a nil security context constraint", func() {
+		var scc *securityv1.SecurityContextConstraints
+		_, err := AllowPrivilegedContainers().Match(scc)
+		Expect(err).Should(HaveOccurred())
+	})
+})
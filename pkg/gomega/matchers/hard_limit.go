@@ -0,0 +1,44 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+	quotav1 "github.com/openshift/api/quota/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// HaveHardLimit is a custom gomega matcher to match on a resource quota or
+// cluster resource quota enforcing the hard limit for the resource
+//
+//	Expect(quota).Should(HaveHardLimit(corev1.ResourcePods, "10"))
+func HaveHardLimit(name corev1.ResourceName, quantity string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(actual any) (bool, error) {
+		expected, err := resource.ParseQuantity(quantity)
+		if err != nil {
+			return false, fmt.Errorf("invalid quantity %q: %w", quantity, err)
+		}
+
+		var hard corev1.ResourceList
+		switch quota := actual.(type) {
+		case *corev1.ResourceQuota:
+			if quota == nil {
+				return false, errors.New("resource quota is nil")
+			}
+			hard = quota.Spec.Hard
+		case *quotav1.ClusterResourceQuota:
+			if quota == nil {
+				return false, errors.New("cluster resource quota is nil")
+			}
+			hard = quota.Spec.Quota.Hard
+		default:
+			return false, fmt.Errorf("HaveHardLimit expects a *ResourceQuota or *ClusterResourceQuota, got %T", actual)
+		}
+
+		limit, ok := hard[name]
+		return ok && limit.Cmp(expected) == 0, nil
+	}).WithTemplate("Expected quota\n{{format .Actual 1}}\n{{.To}} have hard limit {{.Data}}", fmt.Sprintf("%s=%s", name, quantity))
+}
@@ -0,0 +1,40 @@
+package matchers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	quotav1 "github.com/openshift/api/quota/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("hard limit", func() {
+	hard := corev1.ResourceList{
+		corev1.ResourcePods:   resource.MustParse("10"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+
+	It("should match a resource quota", func() {
+		quota := &corev1.ResourceQuota{Spec: corev1.ResourceQuotaSpec{Hard: hard}}
+		Expect(quota).Should(HaveHardLimit(corev1.ResourcePods, "10"))
+		Expect(quota).Should(HaveHardLimit(corev1.ResourceMemory, "1024Mi"))
+		Expect(quota).ShouldNot(HaveHardLimit(corev1.ResourcePods, "20"))
+		Expect(quota).ShouldNot(HaveHardLimit(corev1.ResourceCPU, "1"))
+	})
+
+	It("should match a cluster resource quota", func() {
+		quota := &quotav1.ClusterResourceQuota{Spec: quotav1.ClusterResourceQuotaSpec{
+			Quota: corev1.ResourceQuotaSpec{Hard: hard},
+		}}
+		Expect(quota).Should(HaveHardLimit(corev1.ResourcePods, "10"))
+		Expect(quota).ShouldNot(HaveHardLimit(corev1.ResourceMemory, "2Gi"))
+	})
+
+	It("should error on unsupported input", func() {
+		_, err := HaveHardLimit(corev1.ResourcePods, "10").Match(&corev1.Pod{})
+		Expect(err).Should(HaveOccurred())
+
+		_, err = HaveHardLimit(corev1.ResourcePods, "ten").Match(&corev1.ResourceQuota{})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,26 @@
+package matchers
+
+import (
+	"errors"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BeReady is a custom gomega matcher to match on a node to be ready
+//
+//	Expect(node).Should(BeReady())
+func BeReady() types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(node *corev1.Node) (bool, error) {
+		if node == nil {
+			return false, errors.New("node is nil")
+		}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				return cond.Status == corev1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	}).WithTemplate("Expected node {{.Actual.Name}}\n{{.To}} be ready\nConditions:\n{{format .Actual.Status.Conditions 1}}")
+}
@@ -0,0 +1,46 @@
+package matchers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("node", func() {
+	It("should be ready", func(ctx context.Context) {
+		node := &corev1.Node{
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+		Expect(node).Should(BeReady())
+	})
+
+	It("should not be ready", func(ctx context.Context) {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeMemoryPressure,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+		}
+		Expect(node).ShouldNot(BeReady())
+		Expect(BeReady().FailureMessage(node)).Should(ContainSubstring(string(corev1.NodeMemoryPressure)))
+	})
+})
@@ -0,0 +1,21 @@
+package matchers
+
+import (
+	"errors"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BeInPhase is a custom gomega matcher to match on a pod to be in the provided phase
+//
+//	Expect(pod).Should(BeInPhase(corev1.PodSucceeded))
+func BeInPhase(phase corev1.PodPhase) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(pod *corev1.Pod) (bool, error) {
+		if pod == nil {
+			return false, errors.New("pod is nil")
+		}
+		return pod.Status.Phase == phase, nil
+	}).WithTemplate("Expected pod {{.Actual.Namespace}}/{{.Actual.Name}}\n{{.To}} be in phase {{.Data}}, actual phase: {{.Actual.Status.Phase}}\nContainer statuses:\n{{format .Actual.Status.ContainerStatuses 1}}", phase)
+}
@@ -0,0 +1,36 @@
+package matchers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("pod", func() {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "test", Ready: true},
+			},
+		},
+	}
+
+	It("should be in phase", func(ctx context.Context) {
+		Expect(pod).Should(BeInPhase(corev1.PodRunning))
+	})
+
+	It("should not be in phase", func(ctx context.Context) {
+		Expect(pod).ShouldNot(BeInPhase(corev1.PodSucceeded))
+	})
+
+	It("should report the actual phase on failure", func(ctx context.Context) {
+		message := BeInPhase(corev1.PodSucceeded).FailureMessage(pod)
+		Expect(message).Should(ContainSubstring("actual phase: Running"))
+		Expect(message).Should(ContainSubstring("default/test"))
+	})
+})
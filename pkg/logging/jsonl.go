@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+// teeSink is a logr sink that forwards every log line to each of its sinks
+type teeSink struct {
+	sinks []logr.LogSink
+}
+
+// Init initializes each sink with the runtime info
+func (t *teeSink) Init(info logr.RuntimeInfo) {
+	for _, sink := range t.sinks {
+		sink.Init(info)
+	}
+}
+
+// Enabled returns true when any of the sinks are enabled at the provided level
+func (t *teeSink) Enabled(level int) bool {
+	for _, sink := range t.sinks {
+		if sink.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Info logs a non-error message to each enabled sink
+func (t *teeSink) Info(level int, msg string, keysAndValues ...any) {
+	for _, sink := range t.sinks {
+		if sink.Enabled(level) {
+			sink.Info(level, msg, keysAndValues...)
+		}
+	}
+}
+
+// Error logs an error message to each sink
+func (t *teeSink) Error(err error, msg string, keysAndValues ...any) {
+	for _, sink := range t.sinks {
+		sink.Error(err, msg, keysAndValues...)
+	}
+}
+
+// WithValues returns a new sink with the key value pairs added to each sink
+func (t *teeSink) WithValues(keysAndValues ...any) logr.LogSink {
+	sinks := make([]logr.LogSink, 0, len(t.sinks))
+	for _, sink := range t.sinks {
+		sinks = append(sinks, sink.WithValues(keysAndValues...))
+	}
+	return &teeSink{sinks: sinks}
+}
+
+// WithName returns a new sink with the name appended to each sink
+func (t *teeSink) WithName(name string) logr.LogSink {
+	sinks := make([]logr.LogSink, 0, len(t.sinks))
+	for _, sink := range t.sinks {
+		sinks = append(sinks, sink.WithName(name))
+	}
+	return &teeSink{sinks: sinks}
+}
+
+// WithJSONLFile returns a logger that writes every log line to the provided
+// logger and additionally as a json object per line to the file. The returned
+// close function must be invoked by the caller once logging is finished.
+// Passing the returned logger to a provider captures all of its logs
+//
+//	logger, closeFile, err := logging.WithJSONLFile(ginkgo.GinkgoLogr, fmt.Sprintf("%s/provider.jsonl", artifactDir))
+//	defer closeFile()
+//	provider, err := rosa.New(ctx, token, clientID, clientSecret, ocmclient.Stage, logger)
+func WithJSONLFile(logger logr.Logger, filename string) (logr.Logger, func() error, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return logger, nil, fmt.Errorf("failed to open jsonl log file %s: %w", filename, err)
+	}
+
+	var mutex sync.Mutex
+	jsonLogger := funcr.NewJSON(func(obj string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		_, _ = file.WriteString(obj + "\n")
+	}, funcr.Options{LogTimestamp: true, Verbosity: 10})
+
+	sinks := []logr.LogSink{jsonLogger.GetSink()}
+	if logger.GetSink() != nil {
+		sinks = append([]logr.LogSink{logger.GetSink()}, sinks...)
+	}
+
+	return logr.New(&teeSink{sinks: sinks}), file.Close, nil
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("jsonl file", func() {
+	It("should write log lines as json objects while still logging to the provided logger", func() {
+		filename := filepath.Join(GinkgoT().TempDir(), "provider.jsonl")
+
+		var forwarded []string
+		base := funcr.New(func(prefix, args string) {
+			forwarded = append(forwarded, args)
+		}, funcr.Options{})
+
+		logger, closeFile, err := WithJSONLFile(base, filename)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		logger = logger.WithValues("cluster_id", "123")
+		logger.Info("Cluster is ready!")
+		logger.Error(errors.New("boom"), "Cluster health check failed")
+		Expect(closeFile()).Should(Succeed())
+
+		Expect(forwarded).Should(HaveLen(2))
+
+		file, err := os.Open(filename)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer file.Close()
+
+		var lines []map[string]any
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := make(map[string]any)
+			Expect(json.Unmarshal(scanner.Bytes(), &line)).Should(Succeed())
+			lines = append(lines, line)
+		}
+
+		Expect(lines).Should(HaveLen(2))
+		Expect(lines[0]).Should(HaveKeyWithValue("msg", "Cluster is ready!"))
+		Expect(lines[0]).Should(HaveKeyWithValue("cluster_id", "123"))
+		Expect(lines[1]).Should(HaveKeyWithValue("error", "boom"))
+	})
+})
@@ -1,18 +1,26 @@
 package osd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/openshift/osde2e-common/internal/mask"
+	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
 	"github.com/openshift/osde2e-common/pkg/clients/openshift"
-	"sigs.k8s.io/e2e-framework/klient/wait"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+const defaultClientRetryAttempts = 3
+
 type CloudProvider string
 
 var (
@@ -20,17 +28,39 @@ var (
 	CloudProviderGCP CloudProvider = "gcp"
 )
 
+type BillingModel string
+
+var (
+	BillingModelStandard       BillingModel = "standard"
+	BillingModelMarketplaceAWS BillingModel = "marketplace-aws"
+	BillingModelMarketplaceGCP BillingModel = "marketplace-gcp"
+)
+
+var (
+	// ErrClusterNotFound is returned when no cluster matches the name or id in ocm
+	ErrClusterNotFound = errors.New("cluster not found")
+	// ErrQuotaExceeded is returned when ocm rejects the cluster due to exhausted quota
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
 type CreateClusterOptions struct {
 	SkipHealthCheck bool
 	ArtifactDir     string
+	// DryRun validates the options and sets CreateClusterResult.Body to the
+	// cluster body as json instead of creating the cluster
+	DryRun bool
 
-	Addons             []string
+	Addons             []Addon
+	BaseDomain         string
+	BillingModel       BillingModel
 	CCS                bool
 	ChannelGroup       string
 	CloudProvider      CloudProvider
 	ClusterName        string
 	ComputeMachineType string
 	ComputeNodeCount   int
+	InfraMachineType   string
+	InfraNodeCount     int
 	HTTPProxy          string
 	HTTPSProxy         string
 	FlavorID           string
@@ -39,12 +69,32 @@ type CreateClusterOptions struct {
 	Region             string
 	Version            string
 
+	// DisableWorkloadMonitoring disables user workload monitoring, it is only
+	// honored at install time
+	DisableWorkloadMonitoring bool
+
+	// DefaultNodeLabels are set on the nodes of the default compute machine pool
+	DefaultNodeLabels map[string]string
+	// DefaultNodeTaints are applied to the default compute machine pool once the
+	// cluster is installed, ocm does not accept taints in the cluster nodes
+	DefaultNodeTaints []Taint
+
 	CreateAWSClusterOptions *CreateAWSClusterOptions
 	CreateGCPClusterOptions *CreateGCPClusterOptions
 
 	InstallTimeout     time.Duration
 	HealthCheckTimeout time.Duration
 	ExpirationDuration time.Duration
+
+	// ClientRetryAttempts is the number of attempts to construct the cluster
+	// client once installed, defaults to 3
+	ClientRetryAttempts int
+}
+
+// Addon represents an addon to install with the cluster and its parameters
+type Addon struct {
+	ID         string
+	Parameters map[string]string
 }
 
 type CreateAWSClusterOptions struct {
@@ -65,22 +115,120 @@ type CreateGCPClusterOptions struct {
 	TokenURI                string
 	AuthProviderX509CertURL string
 	ClientX509CertURL       string
+
+	// WorkloadIdentityFederation authenticates using the wif config instead of
+	// the service account key fields above
+	WorkloadIdentityFederation bool
+	WIFConfigID                string
+
+	// VPCName, ControlPlaneSubnet and ComputeSubnet install the cluster into an
+	// existing vpc, VPCProjectID is only required for shared vpcs
+	VPCName            string
+	VPCProjectID       string
+	ControlPlaneSubnet string
+	ComputeSubnet      string
+	// Private restricts the api to internal listening, requires an existing vpc
+	Private bool
+}
+
+// MarshalLog returns the options to log with the cloud credentials and the
+// credentials of the proxy urls masked
+func (o *CreateClusterOptions) MarshalLog() any {
+	// the copy drops the MarshalLog method so it is logged as a plain struct
+	type createClusterOptions CreateClusterOptions
+
+	masked := createClusterOptions(*o)
+	masked.HTTPProxy = mask.URLCredentials(o.HTTPProxy)
+	masked.HTTPSProxy = mask.URLCredentials(o.HTTPSProxy)
+
+	if o.CreateAWSClusterOptions != nil {
+		aws := *o.CreateAWSClusterOptions
+		aws.AccessKeyID = mask.Credential(aws.AccessKeyID)
+		aws.SecretAccessKey = mask.Credential(aws.SecretAccessKey)
+		masked.CreateAWSClusterOptions = &aws
+	}
+
+	if o.CreateGCPClusterOptions != nil {
+		gcp := *o.CreateGCPClusterOptions
+		gcp.PrivateKey = mask.Credential(gcp.PrivateKey)
+		gcp.PrivateKeyID = mask.Credential(gcp.PrivateKeyID)
+		masked.CreateGCPClusterOptions = &gcp
+	}
+
+	return masked
+}
+
+// hasNetwork returns true when any of the network options are set
+func (o *CreateGCPClusterOptions) hasNetwork() bool {
+	return o.VPCName != "" || o.VPCProjectID != "" || o.ControlPlaneSubnet != "" || o.ComputeSubnet != "" || o.Private
 }
 
 type DeleteClusterOptions struct {
 	ClusterID       string
 	WaitForDeletion bool
+	// UninstallTimeout bounds the wait for deletion, defaults to 30 minutes
+	UninstallTimeout time.Duration
+}
+
+// GetCluster returns the ocm cluster
+func (p *Provider) GetCluster(ctx context.Context, clusterID string) (*cmv1.Cluster, error) {
+	var cluster *cmv1.Cluster
+	err := p.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := p.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		cluster = response.Body()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster %s: %w", clusterID, err)
+	}
+	return cluster, nil
 }
 
-// CreateCluster creates an OSD cluster using the provided inputs
+// ClusterState returns the ocm clusters state
+func (p *Provider) ClusterState(ctx context.Context, clusterID string) (cmv1.ClusterState, error) {
+	cluster, err := p.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+	return cluster.State(), nil
+}
+
+// CreateClusterResult represents the outcome of creating a cluster
+type CreateClusterResult struct {
+	ClusterID string
+	// Body is the cluster body as json, only set for dry runs. It includes the
+	// cloud credentials and should not be logged
+	Body string
+}
+
+// CreateCluster creates an OSD cluster using the provided inputs. Dry runs
+// return no cluster id, use CreateClusterWithResult for their cluster body
 func (p *Provider) CreateCluster(ctx context.Context, options *CreateClusterOptions) (string, error) {
+	result, err := p.CreateClusterWithResult(ctx, options)
+	if result == nil {
+		return "", err
+	}
+	return result.ClusterID, err
+}
+
+// CreateClusterWithResult creates an OSD cluster using the provided inputs and
+// returns its id, or the cluster body for dry runs
+func (p *Provider) CreateClusterWithResult(ctx context.Context, options *CreateClusterOptions) (*CreateClusterResult, error) {
+	p = p.withOperationID()
+
 	options, err := p.validateCreateClusterOptions(options)
 	if err != nil {
-		return "", fmt.Errorf("invalid CreateClusterOptions: %w", err)
+		return nil, fmt.Errorf("invalid CreateClusterOptions: %w", err)
 	}
 
+	p.log.Info("Creating cluster", "name", options.ClusterName, "options", options)
+
 	regionBuilder := cmv1.NewCloudRegion().ID(options.Region)
 	newCluster := cmv1.NewCluster().
+		BillingModel(cmv1.BillingModel(options.BillingModel)).
 		CloudProvider(cmv1.NewCloudProvider().ID(string(options.CloudProvider))).
 		Flavour(cmv1.NewFlavour().ID(options.FlavorID)).
 		MultiAZ(options.MultiAZ).
@@ -89,19 +237,19 @@ func (p *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 		Region(regionBuilder).
 		Version(cmv1.NewVersion().ID(options.Version).ChannelGroup(options.ChannelGroup))
 
-	if len(options.Addons) > 0 {
-		addons := []*cmv1.AddOnInstallationBuilder{}
-		for _, addon := range options.Addons {
-			addons = append(addons, cmv1.NewAddOnInstallation().Addon(cmv1.NewAddOn().ID(addon)))
-		}
-		newCluster.Addons(cmv1.NewAddOnInstallationList().Items(addons...))
+	if options.DisableWorkloadMonitoring {
+		newCluster.DisableUserWorkloadMonitoring(true)
+	}
+
+	if options.BaseDomain != "" {
+		newCluster.DNS(cmv1.NewDNS().BaseDomain(options.BaseDomain))
 	}
 
 	if options.ExpirationDuration > 0 {
 		newCluster.ExpirationTimestamp(time.Now().Add(options.ExpirationDuration).UTC())
 	}
 
-	nodeBuilder := cmv1.NewClusterNodes().Compute(options.ComputeNodeCount)
+	nodeBuilder := buildNodes(options)
 
 	if options.CCS {
 		newCluster.CCS(cmv1.NewCCS().Enabled(true))
@@ -118,11 +266,11 @@ func (p *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 				// TODO: do availability zone stuff
 				awsProviderData, err := cmv1.NewCloudProviderData().AWS(awsBuilder).Region(regionBuilder).Build()
 				if err != nil {
-					return "", fmt.Errorf("failed to build CloudProviderData object: %w", err)
+					return nil, fmt.Errorf("failed to build CloudProviderData object: %w", err)
 				}
 				vpcsSearchResp, err := p.ClustersMgmt().V1().AWSInquiries().Vpcs().Search().Page(1).Size(-1).Body(awsProviderData).SendContext(ctx)
 				if err != nil {
-					return "", fmt.Errorf("unable to search for VPCs in AWS: %w", err)
+					return nil, fmt.Errorf("unable to search for VPCs in AWS: %w", err)
 				}
 
 				// what in tarnation is going on here
@@ -144,120 +292,251 @@ func (p *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 
 			newCluster.AWS(awsBuilder)
 		case CloudProviderGCP:
-			// set GCP options
-			gcpBuilder := cmv1.NewGCP().
-				Type(options.CreateGCPClusterOptions.Type).
-				ProjectID(options.CreateGCPClusterOptions.ProjectID).
-				PrivateKey(options.CreateGCPClusterOptions.PrivateKey).
-				PrivateKeyID(options.CreateGCPClusterOptions.PrivateKeyID).
-				ClientEmail(options.CreateGCPClusterOptions.ClientEmail).
-				ClientID(options.CreateGCPClusterOptions.ClientID).
-				AuthURI(options.CreateGCPClusterOptions.AuthURI).
-				TokenURI(options.CreateGCPClusterOptions.TokenURI).
-				AuthProviderX509CertURL(options.CreateGCPClusterOptions.AuthProviderX509CertURL).
-				ClientX509CertURL(options.CreateGCPClusterOptions.ClientX509CertURL)
-
-			newCluster.GCP(gcpBuilder)
-		}
-	}
+			if options.CreateGCPClusterOptions.WorkloadIdentityFederation {
+				if err = p.wifConfigCheck(ctx, options.CreateGCPClusterOptions.WIFConfigID); err != nil {
+					return nil, err
+				}
+			}
 
-	if options.MultiAZ {
-		// Default to 9 nodes for MultiAZ
-		nodeBuilder.Compute(9)
-		if options.ComputeNodeCount > 0 {
-			nodeBuilder.Compute(options.ComputeNodeCount)
-		}
-		newCluster.MultiAZ(options.MultiAZ)
-	}
+			newCluster.GCP(gcpBuilder(options.CreateGCPClusterOptions))
 
-	if options.ComputeMachineType != "" {
-		nodeBuilder.ComputeMachineType(cmv1.NewMachineType().ID(options.ComputeMachineType))
+			if options.CreateGCPClusterOptions.VPCName != "" {
+				newCluster.GCPNetwork(cmv1.NewGCPNetwork().
+					VPCName(options.CreateGCPClusterOptions.VPCName).
+					VPCProjectID(options.CreateGCPClusterOptions.VPCProjectID).
+					ControlPlaneSubnet(options.CreateGCPClusterOptions.ControlPlaneSubnet).
+					ComputeSubnet(options.CreateGCPClusterOptions.ComputeSubnet))
+			}
+
+			if options.CreateGCPClusterOptions.Private {
+				newCluster.API(cmv1.NewClusterAPI().Listening(cmv1.ListeningMethodInternal))
+			}
+		}
 	}
 
 	newCluster.Nodes(nodeBuilder)
 
-	if len(options.Addons) > 0 {
-		// TODO: this doesn't feel correct, is there a `New*` function to use?
-		addons := []*cmv1.AddOnInstallationBuilder{}
-		for _, addon := range options.Addons {
-			addons = append(addons, cmv1.NewAddOnInstallation().Addon(cmv1.NewAddOn().ID(addon)))
-		}
-		newCluster.Addons(cmv1.NewAddOnInstallationList().Items(addons...))
+	if addons := buildAddons(options); addons != nil {
+		newCluster.Addons(addons)
 	}
 
 	body, err := newCluster.Build()
 	if err != nil {
-		return "", fmt.Errorf("unable to build cluster object: %w", err)
+		return nil, fmt.Errorf("unable to build cluster object: %w", err)
+	}
+
+	if options.DryRun {
+		var buf bytes.Buffer
+		if err = cmv1.MarshalCluster(body, &buf); err != nil {
+			return nil, fmt.Errorf("unable to marshal cluster object: %w", err)
+		}
+		p.log.Info("Dry run, skipping cluster creation", "name", options.ClusterName, ocmEnvironmentLoggerKey, p.ocmEnvironment)
+		return &CreateClusterResult{Body: buf.String()}, nil
 	}
 
 	response, err := p.ClustersMgmt().V1().Clusters().Add().Body(body).SendContext(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed sending cluster creation body: %w", err)
+		return nil, fmt.Errorf("failed sending cluster creation body: %w", quotaError(err))
 	}
 
 	cluster := response.Body()
 	clusterID := cluster.ID()
 
-	p.log.Info("Cluster created, waiting for installed state", "id", clusterID, "state", cluster.State())
+	product, billingModel := ocmclient.ClusterProductOf(cluster)
+	p.log.Info("Cluster created, waiting for installed state", "id", clusterID, "state", cluster.State(),
+		"product", product, "billing_model", billingModel)
 
-	err = wait.For(func(ctx context.Context) (bool, error) {
-		clusterResp, err := p.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	err = p.waitUntil(ctx, 30*time.Second, options.InstallTimeout, func(ctx context.Context) (bool, error) {
+		cluster, err = p.GetCluster(ctx, clusterID)
 		if err != nil {
 			return false, err
 		}
-		cluster = clusterResp.Body()
 		if cluster.State() == cmv1.ClusterStateError || cluster.State() == cmv1.ClusterStateUninstalling {
 			return false, fmt.Errorf("cluster %s is in a bad state %s", clusterID, cluster.State())
 		}
 		return cluster.State() == cmv1.ClusterStateReady, nil
-	}, wait.WithTimeout(options.InstallTimeout), wait.WithInterval(30*time.Second), wait.WithContext(ctx))
+	})
 	if err != nil {
-		return "", fmt.Errorf("cluster never reached an installed state: %w", err)
+		// the wait context may be expired, collect with a fresh one
+		collectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Minute)
+		defer cancel()
+		p.collectInstallLog(collectCtx, clusterID, options.ClusterName, options.ArtifactDir)
+		return &CreateClusterResult{ClusterID: clusterID}, fmt.Errorf("cluster never reached an installed state: %w", err)
 	}
 
 	p.log.Info("Cluster installed", "id", clusterID, "state", cluster.State())
 
+	if len(options.DefaultNodeTaints) != 0 {
+		if err = p.taintMachinePool(ctx, clusterID, defaultMachinePoolID, options.DefaultNodeTaints); err != nil {
+			return &CreateClusterResult{ClusterID: clusterID}, err
+		}
+	}
+
 	if !options.SkipHealthCheck {
 		p.log.Info("Waiting for cluster to be healthy", "id", clusterID)
-		kubeconfigFile, err := p.KubeconfigFile(ctx, cluster.ID(), os.TempDir())
-		if err != nil {
-			return clusterID, err
-		}
-		client, err := openshift.NewFromKubeconfig(kubeconfigFile, p.log)
+		client, err := p.clusterClient(ctx, clusterID, options.ClientRetryAttempts)
 		if err != nil {
-			return clusterID, err
+			return &CreateClusterResult{ClusterID: clusterID}, err
 		}
-		if err = client.OSDClusterHealthy(ctx, options.ArtifactDir, options.HealthCheckTimeout); err != nil {
-			return clusterID, err
+		if err = p.waitForClusterToBeHealthy(ctx, client, cluster, options.ArtifactDir, options.HealthCheckTimeout); err != nil {
+			return &CreateClusterResult{ClusterID: clusterID}, err
 		}
 	}
 
-	return clusterID, nil
+	return &CreateClusterResult{ClusterID: clusterID}, nil
+}
+
+// WaitForClusterHealthy waits for an installed cluster to pass the health check,
+// used when the health check was skipped at cluster creation
+func (p *Provider) WaitForClusterHealthy(ctx context.Context, clusterID, reportDir string, timeout time.Duration) error {
+	cluster, err := p.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.clusterClient(ctx, clusterID, defaultClientRetryAttempts)
+	if err != nil {
+		return err
+	}
+
+	return p.waitForClusterToBeHealthy(ctx, client, cluster, reportDir, timeout)
+}
+
+// ClusterHandle represents an existing cluster adopted by the provider
+type ClusterHandle struct {
+	ID   string
+	Name string
+	// Product and BillingModel are the ocm product (e.g. rosa, osd) and billing
+	// model (e.g. standard, marketplace-aws) the cluster was created with
+	Product      string
+	BillingModel string
+	Client       *openshift.Client
+}
+
+// AdoptCluster returns a handle to an existing ready cluster found by name or
+// id, used to test clusters provisioned outside of the providers session
+func (p *Provider) AdoptCluster(ctx context.Context, clusterName string) (*ClusterHandle, error) {
+	return p.adoptCluster(ctx, clusterName, p.findCluster, p.clusterClient)
+}
+
+// adoptCluster looks up the ready cluster and constructs its client
+func (p *Provider) adoptCluster(ctx context.Context, clusterName string,
+	findCluster func(context.Context, string) (*cmv1.Cluster, error),
+	clusterClient func(context.Context, string, int) (*openshift.Client, error),
+) (*ClusterHandle, error) {
+	cluster, err := findCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		return nil, fmt.Errorf("cluster %q is %s, expected %s", clusterName, cluster.State(), cmv1.ClusterStateReady)
+	}
+
+	client, err := clusterClient(ctx, cluster.ID(), defaultClientRetryAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	product, billingModel := ocmclient.ClusterProductOf(cluster)
+
+	p.log.Info("Cluster adopted!", clusterIDLoggerKey, cluster.ID(), "name", cluster.Name(),
+		"product", product, "billing_model", billingModel)
+
+	return &ClusterHandle{ID: cluster.ID(), Name: cluster.Name(), Product: product, BillingModel: billingModel, Client: client}, nil
+}
+
+// findCluster returns the ocm cluster with the name or id
+func (p *Provider) findCluster(ctx context.Context, clusterName string) (*cmv1.Cluster, error) {
+	var response *cmv1.ClustersListResponse
+	err := p.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = p.ClustersMgmt().V1().Clusters().List().
+			Search(fmt.Sprintf("name = '%[1]s' OR id = '%[1]s'", clusterName)).
+			Size(1).
+			SendContext(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find cluster %s: %w", clusterName, err)
+	}
+
+	if response.Total() != 1 {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+	}
+
+	return response.Items().Get(0), nil
+}
+
+// waitForClusterToBeHealthy runs the health check matching the clusters topology
+func (p *Provider) waitForClusterToBeHealthy(ctx context.Context, client *openshift.Client, cluster *cmv1.Cluster, reportDir string, timeout time.Duration) error {
+	if cluster.Hypershift().Enabled() {
+		return client.HCPClusterHealthy(ctx, cluster.Nodes().Compute(), timeout)
+	}
+	return client.OSDClusterHealthy(ctx, reportDir, timeout)
 }
 
 // DeleteCluster deletes a osd cluster using the provided inputs
 func (p *Provider) DeleteCluster(ctx context.Context, options *DeleteClusterOptions) error {
-	clusterClient := p.ClustersMgmt().V1().Clusters().Cluster(options.ClusterID)
-	clusterGetResp, err := clusterClient.Get().SendContext(ctx)
+	p = p.withOperationID()
+
+	if options.UninstallTimeout == 0 {
+		options.UninstallTimeout = 30 * time.Minute
+	}
+
+	cluster, err := p.GetCluster(ctx, options.ClusterID)
 	if err != nil {
-		return fmt.Errorf("unable to get cluster %s: %w", options.ClusterID, err)
+		return err
 	}
-	cluster := clusterGetResp.Body()
 
 	if cluster.State() == cmv1.ClusterStateUninstalling {
 		p.log.Info("Cluster is already uninstalling", "id", cluster.ID())
-		return nil
+	} else {
+		_, err = p.ClustersMgmt().V1().Clusters().Cluster(options.ClusterID).Delete().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("deleting cluster failed: %w", err)
+		}
 	}
 
-	_, err = clusterClient.Delete().SendContext(ctx)
-	if err != nil {
-		return fmt.Errorf("deleting cluster failed: %w", err)
+	if options.WaitForDeletion {
+		return p.waitForClusterToBeDeleted(ctx, options.ClusterID, options.UninstallTimeout, p.GetCluster)
 	}
 
-	if options.WaitForDeletion {
-		// TODO: wait for cluster to be deleted
-		return nil
+	return nil
+}
+
+// quotaError marks ocm errors rejecting a request due to exhausted quota with ErrQuotaExceeded
+func quotaError(err error) error {
+	var ocmErr *ocmerrors.Error
+	if errors.As(err, &ocmErr) && (ocmErr.Status() == http.StatusPaymentRequired || strings.Contains(strings.ToLower(ocmErr.Reason()), "quota")) {
+		return fmt.Errorf("%w: %w", ErrQuotaExceeded, err)
 	}
+	return err
+}
+
+// waitForClusterToBeDeleted waits until ocm no longer returns the cluster
+func (p *Provider) waitForClusterToBeDeleted(ctx context.Context, clusterID string, timeout time.Duration, getCluster func(context.Context, string) (*cmv1.Cluster, error)) error {
+	p.log.Info("Waiting for cluster to be deleted", clusterIDLoggerKey, clusterID)
+
+	err := p.waitUntil(ctx, 30*time.Second, timeout, func(ctx context.Context) (bool, error) {
+		cluster, err := getCluster(ctx, clusterID)
+		if err != nil {
+			var ocmErr *ocmerrors.Error
+			if errors.As(err, &ocmErr) && ocmErr.Status() == http.StatusNotFound {
+				return true, nil
+			}
+			return false, err
+		}
+		if cluster.State() == cmv1.ClusterStateError {
+			return false, fmt.Errorf("cluster %s is in a bad state %s", clusterID, cluster.State())
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cluster %s was not deleted: %w", clusterID, err)
+	}
+
+	p.log.Info("Cluster deleted!", clusterIDLoggerKey, clusterID)
 
 	return nil
 }
@@ -270,35 +549,253 @@ func (p *Provider) validateCreateClusterOptions(options *CreateClusterOptions) (
 		options.ArtifactDir = os.TempDir()
 	}
 
+	// expiration can not be set on production, skip
+	if options.ExpirationDuration > 0 && p.ocmEnvironment == ocmclient.Production {
+		p.log.Info("Ignoring ExpirationDuration, cluster expiration can not be set on production",
+			"expiration_duration", options.ExpirationDuration.String(), ocmEnvironmentLoggerKey, p.ocmEnvironment)
+		options.ExpirationDuration = 0
+	}
+
+	if options.ClientRetryAttempts == 0 {
+		options.ClientRetryAttempts = defaultClientRetryAttempts
+	}
+
 	if options.FlavorID == "" {
 		options.FlavorID = "osd-4"
 	}
 
+	if options.BillingModel == "" {
+		options.BillingModel = BillingModelStandard
+	}
+
+	var errs []error
+
+	if err := validateBillingModel(options.BillingModel, options.CloudProvider); err != nil {
+		errs = append(errs, err)
+	}
+
 	if options.ComputeNodeCount <= 0 {
-		return options, fmt.Errorf("invalid CreateClusterOptions: ComputeNodeCount must be greater than 0. Got %d", options.ComputeNodeCount)
+		errs = append(errs, fmt.Errorf("ComputeNodeCount must be greater than 0. Got %d", options.ComputeNodeCount))
+	}
+
+	if options.BaseDomain != "" {
+		if msgs := validation.IsDNS1123Subdomain(options.BaseDomain); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("BaseDomain %q is not a valid dns name: %s", options.BaseDomain, strings.Join(msgs, ", ")))
+		}
+	}
+
+	for _, addon := range options.Addons {
+		if addon.ID == "" {
+			errs = append(errs, errors.New("addon ID must be set"))
+			break
+		}
+	}
+
+	for key, value := range options.DefaultNodeLabels {
+		if msgs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("DefaultNodeLabels %q=%q is not a valid label: %s", key, value, strings.Join(msgs, ", ")))
+		}
+	}
+
+	errs = append(errs, validateTaints(options.DefaultNodeTaints)...)
+
+	if options.InfraNodeCount < 0 {
+		errs = append(errs, fmt.Errorf("InfraNodeCount must not be negative. Got %d", options.InfraNodeCount))
 	}
 
 	if options.MultiAZ {
 		if options.ComputeNodeCount > 0 && math.Mod(float64(options.ComputeNodeCount), float64(3)) != 0 {
-			return options, fmt.Errorf("invalid CreateClusterOptions: MultiAZ requires ComputeNodeCount to be divisible by 3. Got %d", options.ComputeNodeCount)
+			errs = append(errs, fmt.Errorf("MultiAZ requires ComputeNodeCount to be divisible by 3. Got %d", options.ComputeNodeCount))
+		}
+		if options.InfraNodeCount > 0 && math.Mod(float64(options.InfraNodeCount), float64(3)) != 0 {
+			errs = append(errs, fmt.Errorf("MultiAZ requires InfraNodeCount to be divisible by 3. Got %d", options.InfraNodeCount))
 		}
 	}
 
+	if options.CreateGCPClusterOptions != nil && options.CreateGCPClusterOptions.hasNetwork() && (!options.CCS || options.CloudProvider != CloudProviderGCP) {
+		errs = append(errs, errors.New("GCP network options can only be used with GCP CCS clusters"))
+	}
+
 	if options.CCS {
 		switch options.CloudProvider {
 		case CloudProviderAWS:
 			if options.CreateAWSClusterOptions == nil {
-				return options, errors.New("invalid CreateClusterOptions: CreateAWSClusterOptions must be set for AWS CCS clusters")
-			}
-			if options.CreateAWSClusterOptions.AccountID == "" || options.CreateAWSClusterOptions.AccessKeyID == "" || options.CreateAWSClusterOptions.SecretAccessKey == "" {
-				return options, errors.New("invalid CreateClusterOptions: AccountID, AccessKeyID, and SecretAccessKey must be set for AWS CCS clusters")
+				errs = append(errs, errors.New("CreateAWSClusterOptions must be set for AWS CCS clusters"))
+			} else if options.CreateAWSClusterOptions.AccountID == "" || options.CreateAWSClusterOptions.AccessKeyID == "" || options.CreateAWSClusterOptions.SecretAccessKey == "" {
+				errs = append(errs, errors.New("AccountID, AccessKeyID, and SecretAccessKey must be set for AWS CCS clusters"))
 			}
 		case CloudProviderGCP:
 			if options.CreateGCPClusterOptions == nil {
-				return options, errors.New("invalid CreateClusterOptions: CreateGCPClusterOptions must be set for GCP CCS clusters")
+				errs = append(errs, errors.New("CreateGCPClusterOptions must be set for GCP CCS clusters"))
+				break
+			}
+			if err := p.validateGCPAuthentication(options.CreateGCPClusterOptions); err != nil {
+				errs = append(errs, err)
+			}
+			if err := validateGCPNetwork(options.CreateGCPClusterOptions); err != nil {
+				errs = append(errs, err)
 			}
 		}
 	}
 
+	if len(errs) != 0 {
+		return options, fmt.Errorf("invalid CreateClusterOptions: %w", errors.Join(errs...))
+	}
+
 	return options, nil
 }
+
+// validateBillingModel verifies the billing model is supported and a marketplace
+// billing model matches the clusters cloud provider
+func validateBillingModel(billingModel BillingModel, cloudProvider CloudProvider) error {
+	switch billingModel {
+	case BillingModelStandard:
+		return nil
+	case BillingModelMarketplaceAWS:
+		if cloudProvider != CloudProviderAWS {
+			return fmt.Errorf("billing model %q requires cloud provider %q. Got %q", billingModel, CloudProviderAWS, cloudProvider)
+		}
+	case BillingModelMarketplaceGCP:
+		if cloudProvider != CloudProviderGCP {
+			return fmt.Errorf("billing model %q requires cloud provider %q. Got %q", billingModel, CloudProviderGCP, cloudProvider)
+		}
+	default:
+		return fmt.Errorf("unsupported billing model %q", billingModel)
+	}
+	return nil
+}
+
+// buildNodes returns the cluster nodes builder for the compute and infra node options
+func buildNodes(options *CreateClusterOptions) *cmv1.ClusterNodesBuilder {
+	nodeBuilder := cmv1.NewClusterNodes().Compute(options.ComputeNodeCount)
+
+	if options.MultiAZ {
+		// Default to 9 nodes for MultiAZ
+		nodeBuilder.Compute(9)
+		if options.ComputeNodeCount > 0 {
+			nodeBuilder.Compute(options.ComputeNodeCount)
+		}
+	}
+
+	if options.ComputeMachineType != "" {
+		nodeBuilder.ComputeMachineType(cmv1.NewMachineType().ID(options.ComputeMachineType))
+	}
+
+	if len(options.DefaultNodeLabels) != 0 {
+		nodeBuilder.ComputeLabels(options.DefaultNodeLabels)
+	}
+
+	if options.InfraNodeCount > 0 {
+		nodeBuilder.Infra(options.InfraNodeCount)
+	}
+
+	if options.InfraMachineType != "" {
+		nodeBuilder.InfraMachineType(cmv1.NewMachineType().ID(options.InfraMachineType))
+	}
+
+	return nodeBuilder
+}
+
+// buildAddons returns the addon installations builder for the addons and their
+// parameters, nil is returned when no addons are requested
+func buildAddons(options *CreateClusterOptions) *cmv1.AddOnInstallationListBuilder {
+	if len(options.Addons) == 0 {
+		return nil
+	}
+
+	installations := make([]*cmv1.AddOnInstallationBuilder, 0, len(options.Addons))
+	for _, addon := range options.Addons {
+		installation := cmv1.NewAddOnInstallation().Addon(cmv1.NewAddOn().ID(addon.ID))
+
+		if len(addon.Parameters) > 0 {
+			parameters := make([]*cmv1.AddOnInstallationParameterBuilder, 0, len(addon.Parameters))
+			for id, value := range addon.Parameters {
+				parameters = append(parameters, cmv1.NewAddOnInstallationParameter().ID(id).Value(value))
+			}
+			installation.Parameters(cmv1.NewAddOnInstallationParameterList().Items(parameters...))
+		}
+
+		installations = append(installations, installation)
+	}
+	return cmv1.NewAddOnInstallationList().Items(installations...)
+}
+
+// validateGCPAuthentication verifies exactly one of the service account key or workload identity federation is used
+func (p *Provider) validateGCPAuthentication(options *CreateGCPClusterOptions) error {
+	serviceAccountKey := options.PrivateKey != "" || options.PrivateKeyID != "" || options.ClientEmail != ""
+
+	if !options.WorkloadIdentityFederation {
+		if !serviceAccountKey {
+			return errors.New("PrivateKey, PrivateKeyID and ClientEmail must be set for GCP CCS clusters not using WorkloadIdentityFederation")
+		}
+		return nil
+	}
+
+	if serviceAccountKey {
+		return errors.New("service account key fields can not be set when using WorkloadIdentityFederation")
+	}
+
+	if options.WIFConfigID == "" {
+		return errors.New("WIFConfigID must be set when using WorkloadIdentityFederation")
+	}
+
+	switch p.ocmEnvironment {
+	case ocmclient.FedRampProduction, ocmclient.FedRampStage, ocmclient.FedRampIntegration:
+		return fmt.Errorf("WorkloadIdentityFederation is not supported in ocm environment %q", p.ocmEnvironment)
+	}
+
+	return nil
+}
+
+// validateGCPNetwork verifies the existing vpc options are set together
+func validateGCPNetwork(options *CreateGCPClusterOptions) error {
+	if !options.hasNetwork() {
+		return nil
+	}
+
+	if options.VPCName == "" || options.ControlPlaneSubnet == "" || options.ComputeSubnet == "" {
+		if options.Private {
+			return errors.New("VPCName, ControlPlaneSubnet and ComputeSubnet must be set for private GCP clusters")
+		}
+		return errors.New("VPCName, ControlPlaneSubnet and ComputeSubnet must all be set to use an existing GCP vpc")
+	}
+
+	return nil
+}
+
+// wifConfigCheck verifies the wif config exists in the ocm environment
+func (p *Provider) wifConfigCheck(ctx context.Context, wifConfigID string) error {
+	_, err := p.ClustersMgmt().V1().GCP().WifConfigs().WifConfig(wifConfigID).Get().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wif config %q is not available in ocm environment %q, the environment may not support WorkloadIdentityFederation: %w",
+			wifConfigID, p.ocmEnvironment, err)
+	}
+	return nil
+}
+
+// gcpBuilder returns the gcp cluster builder authenticating with either the wif config or the service account key
+func gcpBuilder(options *CreateGCPClusterOptions) *cmv1.GCPBuilder {
+	if options.WorkloadIdentityFederation {
+		return cmv1.NewGCP().
+			ProjectID(options.ProjectID).
+			Authentication(cmv1.NewGcpAuthentication().Kind(cmv1.WifConfigKind).Id(options.WIFConfigID))
+	}
+
+	return cmv1.NewGCP().
+		Type(options.Type).
+		ProjectID(options.ProjectID).
+		PrivateKey(options.PrivateKey).
+		PrivateKeyID(options.PrivateKeyID).
+		ClientEmail(options.ClientEmail).
+		ClientID(options.ClientID).
+		AuthURI(options.AuthURI).
+		TokenURI(options.TokenURI).
+		AuthProviderX509CertURL(options.AuthProviderX509CertURL).
+		ClientX509CertURL(options.ClientX509CertURL)
+}
+
+// clusterClient constructs the cluster client, retrying as the admin credentials
+// can take a moment to propagate after the cluster becomes ready
+func (p *Provider) clusterClient(ctx context.Context, clusterID string, attempts int) (*openshift.Client, error) {
+	return p.Client.ClusterClient(ctx, clusterID, p.PollStrategy.WithDefaultInterval(30*time.Second), attempts, p.log)
+}
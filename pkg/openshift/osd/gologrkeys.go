@@ -3,5 +3,7 @@ package osd
 // Constants defining commonly used go-logr keys
 const (
 	clusterIDLoggerKey      = "cluster_id"
+	machinePoolIDLoggerKey  = "machine_pool_id"
 	ocmEnvironmentLoggerKey = "ocm_environment"
+	operationIDLoggerKey    = "operation_id"
 )
@@ -0,0 +1,61 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ClusterInstallLog writes the clusters install log from ocm to the destination file
+func (o *Provider) ClusterInstallLog(ctx context.Context, clusterID, destFile string) error {
+	getLog := func(ctx context.Context) (string, error) {
+		var content string
+		err := o.RetryOnAuthError(ctx, func(ctx context.Context) error {
+			response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Logs().Install().Get().SendContext(ctx)
+			if err != nil {
+				return err
+			}
+			content = response.Body().Content()
+			return nil
+		})
+		return content, err
+	}
+
+	o.log.Info("Get cluster install log", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	if err := writeClusterLog(ctx, getLog, destFile); err != nil {
+		return fmt.Errorf("failed to get cluster %q install log: %w", clusterID, err)
+	}
+
+	o.log.Info("Cluster install log retrieved!", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	return nil
+}
+
+// collectInstallLog writes the install log to the artifact directory when the
+// cluster fails to install, nothing is collected without an artifact directory
+func (o *Provider) collectInstallLog(ctx context.Context, clusterID, clusterName, artifactDir string) {
+	if artifactDir == "" {
+		return
+	}
+
+	installLog := filepath.Join(artifactDir, fmt.Sprintf("%s-install.log", clusterName))
+	if err := o.ClusterInstallLog(ctx, clusterID, installLog); err != nil {
+		o.log.Error(err, "failed to collect cluster install log", clusterIDLoggerKey, clusterID)
+	}
+}
+
+// writeClusterLog writes the log returned by getLog to the destination file
+func writeClusterLog(ctx context.Context, getLog func(ctx context.Context) (string, error), destFile string) error {
+	content, err := getLog(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(destFile, []byte(content), os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("failed to write log to file: %w", err)
+	}
+
+	return nil
+}
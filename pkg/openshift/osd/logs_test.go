@@ -0,0 +1,97 @@
+package osd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
+)
+
+// mockedOCMProvider returns a provider whose ocm connection is served by the handler
+func mockedOCMProvider(handler http.HandlerFunc) *Provider {
+	server := httptest.NewServer(handler)
+	DeferCleanup(server.Close)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	token := fmt.Sprintf("%s.%s.", encode([]byte(`{"alg":"none","typ":"JWT"}`)),
+		encode([]byte(fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix()))))
+
+	connection, err := ocmsdk.NewConnectionBuilder().URL(server.URL).Tokens(token).Build()
+	Expect(err).ShouldNot(HaveOccurred())
+	DeferCleanup(connection.Close)
+
+	return &Provider{Client: &ocmclient.Client{Connection: connection}, log: logr.Discard()}
+}
+
+var _ = Describe("cluster logs", func() {
+	It("should write the log content to the destination file", func(ctx context.Context) {
+		destFile := filepath.Join(GinkgoT().TempDir(), "test-install.log")
+		getLog := func(context.Context) (string, error) {
+			return "level=info msg=\"Waiting up to 40m0s for the cluster\"\n", nil
+		}
+
+		Expect(writeClusterLog(ctx, getLog, destFile)).Should(Succeed())
+
+		content, err := os.ReadFile(destFile)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).Should(Equal("level=info msg=\"Waiting up to 40m0s for the cluster\"\n"))
+	})
+
+	It("should not write the file when the log is unavailable", func(ctx context.Context) {
+		destFile := filepath.Join(GinkgoT().TempDir(), "test-install.log")
+		getLog := func(context.Context) (string, error) {
+			return "", errors.New("status is 404")
+		}
+
+		Expect(writeClusterLog(ctx, getLog, destFile)).Should(MatchError(ContainSubstring("404")))
+		Expect(destFile).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should write the install log returned by ocm", func(ctx context.Context) {
+		provider := mockedOCMProvider(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).Should(Equal("/api/clusters_mgmt/v1/clusters/123/logs/install"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"Log","id":"install","content":"level=error msg=\"failed to install\"\n"}`))
+		})
+		destFile := filepath.Join(GinkgoT().TempDir(), "test-install.log")
+
+		Expect(provider.ClusterInstallLog(ctx, "123", destFile)).Should(Succeed())
+
+		content, err := os.ReadFile(destFile)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).Should(Equal("level=error msg=\"failed to install\"\n"))
+	})
+
+	It("should return the ocm error when the install log is missing", func(ctx context.Context) {
+		provider := mockedOCMProvider(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Error","id":"404","reason":"install log not found"}`))
+		})
+		destFile := filepath.Join(GinkgoT().TempDir(), "test-install.log")
+
+		Expect(provider.ClusterInstallLog(ctx, "123", destFile)).Should(MatchError(ContainSubstring("install log not found")))
+		Expect(destFile).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should not collect the install log without an artifact directory", func(ctx context.Context) {
+		requests := 0
+		provider := mockedOCMProvider(func(http.ResponseWriter, *http.Request) {
+			requests++
+		})
+
+		provider.collectInstallLog(ctx, "123", "test", "")
+		Expect(requests).Should(Equal(0))
+	})
+})
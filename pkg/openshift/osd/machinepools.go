@@ -0,0 +1,211 @@
+package osd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-common/pkg/clients/openshift"
+)
+
+const defaultMachinePoolID = "worker"
+
+// taintEffects are the effects a node taint can have
+var taintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// Taint represents a kubernetes taint applied to the nodes of a machine pool
+type Taint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// MachinePoolSpec represents the machine pool to create
+type MachinePoolSpec struct {
+	ID           string
+	InstanceType string
+
+	// Replicas is the node count, ignored when MaxReplicas enables autoscaling
+	Replicas int
+	// MinReplicas and MaxReplicas enable autoscaling when MaxReplicas is set
+	MinReplicas int
+	MaxReplicas int
+
+	Labels map[string]string
+	Taints []Taint
+}
+
+// CreateMachinePool adds the machine pool to the cluster using ocm
+func (o *Provider) CreateMachinePool(ctx context.Context, clusterID string, spec MachinePoolSpec) error {
+	machinePool, err := buildMachinePool(spec)
+	if err != nil {
+		return fmt.Errorf("invalid machine pool %q for cluster %q: %w", spec.ID, clusterID, err)
+	}
+
+	o.log.Info("Creating machine pool", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, spec.ID,
+		"instance_type", spec.InstanceType, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().Add().Body(machinePool).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create machine pool %q for cluster %q: %w", spec.ID, clusterID, err)
+	}
+
+	o.log.Info("Machine pool created!", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, spec.ID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	return nil
+}
+
+// ListMachinePools returns the clusters machine pools
+func (o *Provider) ListMachinePools(ctx context.Context, clusterID string) ([]*cmv1.MachinePool, error) {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().List().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine pools for cluster %q: %w", clusterID, err)
+	}
+
+	return response.Items().Slice(), nil
+}
+
+// DeleteMachinePool removes the machine pool from the cluster using ocm
+func (o *Provider) DeleteMachinePool(ctx context.Context, clusterID, machinePoolID string) error {
+	o.log.Info("Deleting machine pool", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, machinePoolID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(machinePoolID).Delete().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete machine pool %q for cluster %q: %w", machinePoolID, clusterID, err)
+	}
+
+	return nil
+}
+
+// buildMachinePool validates the spec and builds the ocm machine pool
+func buildMachinePool(spec MachinePoolSpec) (*cmv1.MachinePool, error) {
+	var errs []error
+
+	if spec.ID == "" {
+		errs = append(errs, errors.New("machine pool id is required"))
+	}
+	if spec.InstanceType == "" {
+		errs = append(errs, errors.New("machine pool instance type is required"))
+	}
+	if spec.MaxReplicas > 0 {
+		if spec.MinReplicas < 0 || spec.MinReplicas > spec.MaxReplicas {
+			errs = append(errs, fmt.Errorf("invalid autoscaling replicas, min: %d, max: %d", spec.MinReplicas, spec.MaxReplicas))
+		}
+	} else if spec.Replicas < 0 {
+		errs = append(errs, fmt.Errorf("invalid replicas: %d", spec.Replicas))
+	}
+	errs = append(errs, validateTaints(spec.Taints)...)
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	builder := cmv1.NewMachinePool().
+		ID(spec.ID).
+		InstanceType(spec.InstanceType).
+		Taints(taintBuilders(spec.Taints)...)
+
+	if spec.MaxReplicas > 0 {
+		builder.Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(spec.MinReplicas).MaxReplicas(spec.MaxReplicas))
+	} else {
+		builder.Replicas(spec.Replicas)
+	}
+
+	if len(spec.Labels) != 0 {
+		builder.Labels(spec.Labels)
+	}
+
+	return builder.Build()
+}
+
+// validateTaints verifies each taint has a key and a supported effect
+func validateTaints(taints []Taint) []error {
+	var errs []error
+
+	for _, taint := range taints {
+		if taint.Key == "" {
+			errs = append(errs, fmt.Errorf("taint key is required (value: %q, effect: %q)", taint.Value, taint.Effect))
+		}
+
+		valid := false
+		for _, effect := range taintEffects {
+			if taint.Effect == effect {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			errs = append(errs, fmt.Errorf("taint %q has invalid effect %q, must be one of %v", taint.Key, taint.Effect, taintEffects))
+		}
+	}
+
+	return errs
+}
+
+// taintBuilders returns the ocm taint builders of the taints
+func taintBuilders(taints []Taint) []*cmv1.TaintBuilder {
+	var builders []*cmv1.TaintBuilder
+	for _, taint := range taints {
+		builders = append(builders, cmv1.NewTaint().Key(taint.Key).Value(taint.Value).Effect(taint.Effect))
+	}
+	return builders
+}
+
+// ScaleMachinePool sets the replica count of the machine pool using ocm
+func (o *Provider) ScaleMachinePool(ctx context.Context, clusterID, machinePoolID string, replicas int) error {
+	machinePool, err := cmv1.NewMachinePool().ID(machinePoolID).Replicas(replicas).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build machine pool %q for cluster %q: %v", machinePoolID, clusterID, err)
+	}
+
+	o.log.Info("Scaling machine pool", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, machinePoolID,
+		"replicas", replicas, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(machinePoolID).Update().Body(machinePool).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to scale machine pool %q for cluster %q: %v", machinePoolID, clusterID, err)
+	}
+
+	return nil
+}
+
+// taintMachinePool sets the taints of the machine pool using ocm
+func (o *Provider) taintMachinePool(ctx context.Context, clusterID, machinePoolID string, taints []Taint) error {
+	machinePool, err := cmv1.NewMachinePool().ID(machinePoolID).Taints(taintBuilders(taints)...).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build machine pool %q for cluster %q: %v", machinePoolID, clusterID, err)
+	}
+
+	o.log.Info("Tainting machine pool", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, machinePoolID,
+		"taints", len(taints), ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(machinePoolID).Update().Body(machinePool).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to taint machine pool %q for cluster %q: %w", machinePoolID, clusterID, err)
+	}
+
+	return nil
+}
+
+// scaleDefaultMachinePool scales the default machine pool and waits for the worker nodes to settle
+func (o *Provider) scaleDefaultMachinePool(ctx context.Context, client *openshift.Client, clusterID string, replicas int, timeout time.Duration) error {
+	if err := o.ScaleMachinePool(ctx, clusterID, defaultMachinePoolID, replicas); err != nil {
+		return err
+	}
+
+	cluster, err := o.findCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	if err = client.WaitForMachinePoolNodes(ctx, cluster.Name(), defaultMachinePoolID, replicas, replicas, timeout); err != nil {
+		return fmt.Errorf("cluster %q worker nodes failed to settle: %w", clusterID, err)
+	}
+
+	o.log.Info("Machine pool scaled!", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, defaultMachinePoolID,
+		"replicas", replicas, ocmEnvironmentLoggerKey, o.ocmEnvironment)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package osd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("machine pools", func() {
+	It("should build a machine pool with labels and taints", func() {
+		machinePool, err := buildMachinePool(MachinePoolSpec{
+			ID:           "infra",
+			InstanceType: "m5.xlarge",
+			Replicas:     2,
+			Labels:       map[string]string{"node-role.kubernetes.io/infra": ""},
+			Taints:       []Taint{{Key: "node-role.kubernetes.io/infra", Effect: "NoSchedule"}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(machinePool.ID()).Should(Equal("infra"))
+		Expect(machinePool.InstanceType()).Should(Equal("m5.xlarge"))
+		Expect(machinePool.Replicas()).Should(Equal(2))
+		Expect(machinePool.Labels()).Should(HaveKey("node-role.kubernetes.io/infra"))
+		Expect(machinePool.Taints()).Should(HaveLen(1))
+		Expect(machinePool.Taints()[0].Effect()).Should(Equal("NoSchedule"))
+	})
+
+	It("should build an autoscaling machine pool", func() {
+		machinePool, err := buildMachinePool(MachinePoolSpec{ID: "scale", InstanceType: "m5.xlarge", MinReplicas: 1, MaxReplicas: 3})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(machinePool.Autoscaling().MinReplicas()).Should(Equal(1))
+		Expect(machinePool.Autoscaling().MaxReplicas()).Should(Equal(3))
+		Expect(machinePool.Replicas()).Should(BeZero())
+	})
+
+	It("should return every validation error", func() {
+		_, err := buildMachinePool(MachinePoolSpec{
+			MinReplicas: 3,
+			MaxReplicas: 1,
+			Taints:      []Taint{{Key: "dedicated", Effect: "NoPods"}},
+		})
+		Expect(err).Should(MatchError(And(
+			ContainSubstring("id is required"),
+			ContainSubstring("instance type is required"),
+			ContainSubstring("invalid autoscaling replicas"),
+			ContainSubstring(`invalid effect "NoPods"`),
+		)))
+	})
+})
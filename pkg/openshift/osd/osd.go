@@ -3,9 +3,12 @@ package osd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
+	"github.com/openshift/osde2e-common/pkg/poll"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 )
 
 // Provider is a openshift dedicated "osd" provider
@@ -13,6 +16,10 @@ type Provider struct {
 	*ocmclient.Client
 	log            logr.Logger
 	ocmEnvironment ocmclient.Environment
+
+	// PollStrategy controls the interval of the providers wait loops, each
+	// loop uses its own default interval when unset
+	PollStrategy poll.Strategy
 }
 
 // providerError represents the provider custom error
@@ -25,14 +32,37 @@ func (o *providerError) Error() string {
 	return fmt.Sprintf("failed to construct osd provider: %v", o.err)
 }
 
+// Unwrap returns the error wrapped by providerError
+func (o *providerError) Unwrap() error {
+	return o.err
+}
+
+// withOperationID returns a copy of the provider whose logger includes a
+// unique id used to correlate all log lines of a single operation
+func (o *Provider) withOperationID() *Provider {
+	provider := *o
+	provider.log = o.log.WithValues(operationIDLoggerKey, utilrand.String(8))
+	return &provider
+}
+
+// waitUntil polls the condition using the providers poll strategy, the interval is used when the strategy has none
+func (o *Provider) waitUntil(ctx context.Context, interval, timeout time.Duration, condition func(ctx context.Context) (bool, error)) error {
+	return poll.Until(ctx, o.PollStrategy.WithDefaultInterval(interval), timeout, condition)
+}
+
 // New handles constructing the osd provider which creates a connection
 // to openshift cluster manager "ocm". It is the callers responsibility
-// to close the ocm connection when they are finished (defer provider.Connection.Close())
+// to close the ocm connection when they are finished (defer provider.Client.Close())
 func New(ctx context.Context, token string, clientID string, clientSecret string, ocmEnvironment ocmclient.Environment, logger logr.Logger) (*Provider, error) {
 	if ocmEnvironment == "" || token == "" {
 		return nil, &providerError{err: fmt.Errorf("some parameters are undefined, unable to construct osd provider")}
 	}
 
+	if err := ocmEnvironment.Validate(); err != nil {
+		return nil, &providerError{err: err}
+	}
+	ocmEnvironment = ocmEnvironment.Normalize()
+
 	ocmClient, err := ocmclient.New(ctx, token, clientID, clientSecret, ocmEnvironment)
 	if err != nil {
 		return nil, &providerError{err: err}
@@ -3,6 +3,7 @@ package osd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -26,10 +27,26 @@ const (
 	managedUpgradeOperatorDeploymentName = "managed-upgrade-operator"
 	managedUpgradeOperatorNamespace      = "openshift-managed-upgrade-operator"
 	versionGateLabel                     = "api.openshift.com/gate-ocp"
-	upgradeMaxAttempts                   = 1080
-	upgradeDelay                         = 10
+	defaultUpgradeTimeout                = 3 * time.Hour
+	defaultUpgradeInterval               = 10 * time.Second
 )
 
+// UpgradeOptions represents optional data used when upgrading clusters
+type UpgradeOptions struct {
+	// PreUpgradeScale resizes the default machine pool to the node count before the upgrade
+	PreUpgradeScale int
+	// PostUpgradeScale resizes the default machine pool to the node count after the upgrade
+	PostUpgradeScale int
+
+	ScaleTimeout time.Duration
+	// UpgradeTimeout bounds the wait for the managed upgrade operator to finish
+	// the upgrade, defaults to 3 hours
+	UpgradeTimeout time.Duration
+	// UpgradeInterval is the time between managed upgrade operator status
+	// checks, defaults to 10 seconds
+	UpgradeInterval time.Duration
+}
+
 // upgradeError represents the cluster upgrade custom error
 type upgradeError struct {
 	err error
@@ -40,6 +57,11 @@ func (e *upgradeError) Error() string {
 	return fmt.Sprintf("osd upgrade failed: %v", e.err)
 }
 
+// Unwrap returns the error wrapped by upgradeError
+func (e *upgradeError) Unwrap() error {
+	return e.err
+}
+
 // versionGates returns a list of available version gates from ocm
 func (o *Provider) versionGates(ctx context.Context) (*clustersmgmtv1.VersionGateList, error) {
 	response, err := o.ClustersMgmt().V1().VersionGates().List().SendContext(ctx)
@@ -96,7 +118,7 @@ func (o *Provider) gateAgreementExistForCluster(ctx context.Context, clusterID,
 // addGateAgreement adds a version gate agreement to the cluster ocm resource.
 // Version gate agreement are used to acknowledge the cluster can be upgraded between versions
 func (o *Provider) addGateAgreement(ctx context.Context, clusterID string, currentVersion, upgradeVersion semver.Version) error {
-	if !(currentVersion.Minor() < upgradeVersion.Minor()) {
+	if !gateAgreementRequired(currentVersion, upgradeVersion) {
 		o.log.Info("Gate agreement not required for z stream upgrades", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
 		return nil
 	}
@@ -136,6 +158,15 @@ func (o *Provider) addGateAgreement(ctx context.Context, clusterID string, curre
 	return nil
 }
 
+// gateAgreementRequired returns true for y stream upgrades, moving to a newer
+// major.minor version. Z stream upgrades only change the patch version
+func gateAgreementRequired(currentVersion, upgradeVersion semver.Version) bool {
+	if upgradeVersion.Major() != currentVersion.Major() {
+		return upgradeVersion.Major() > currentVersion.Major()
+	}
+	return upgradeVersion.Minor() > currentVersion.Minor()
+}
+
 // initiateUpgrade initiates the upgrade for the cluster with ocm by applying a upgrade policy to the cluster
 func (o *Provider) initiateUpgrade(ctx context.Context, clusterID, version string) error {
 	upgradePolicy, err := clustersmgmtv1.NewUpgradePolicy().Version(version).
@@ -175,7 +206,7 @@ func (o *Provider) restartManagedUpgradeOperator(ctx context.Context, client *op
 	}
 
 	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: managedUpgradeOperatorDeploymentName, Namespace: managedUpgradeOperatorNamespace}}
-	err := wait.For(conditions.New(client.Resources).DeploymentConditionMatch(deployment, appsv1.DeploymentAvailable, corev1.ConditionTrue))
+	err := o.waitUntil(ctx, 5*time.Second, 5*time.Minute, conditions.New(client.Resources).DeploymentConditionMatch(deployment, appsv1.DeploymentAvailable, corev1.ConditionTrue))
 	if err != nil {
 		return fmt.Errorf("failed to get managed upgrade operator deployment: %v", err)
 	}
@@ -207,36 +238,90 @@ func (o *Provider) restartManagedUpgradeOperator(ctx context.Context, client *op
 
 // managedUpgradeConfigExist waits/checks for the muo upgrade config to exist on the cluster
 func (o *Provider) managedUpgradeConfigExist(ctx context.Context, dynamicClient *dynamic.DynamicClient) error {
-	for i := 1; i <= 6; i++ {
+	err := o.waitUntil(ctx, 30*time.Second, 3*time.Minute, func(ctx context.Context) (bool, error) {
 		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
-		if err != nil || upgradeConfig == nil {
-			time.Sleep(30 * time.Second)
-			continue
+		return err == nil && upgradeConfig != nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("managed upgrade config does not exist the cluster: %w", err)
+	}
+
+	return nil
+}
+
+// OCMUpgrade handles the end to end process to upgrade an openshift dedicated cluster.
+// Upgrade options can optionally be provided to resize the default machine pool around the upgrade
+func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version, args ...*UpgradeOptions) error {
+	o = o.withOperationID()
+
+	options := &UpgradeOptions{}
+	if len(args) == 1 && args[0] != nil {
+		options = args[0]
+	}
+
+	if options.ScaleTimeout == 0 {
+		options.ScaleTimeout = 30 * time.Minute
+	}
+
+	if options.UpgradeTimeout == 0 {
+		options.UpgradeTimeout = defaultUpgradeTimeout
+	}
+
+	if options.UpgradeInterval == 0 {
+		options.UpgradeInterval = defaultUpgradeInterval
+	}
+
+	return upgradeWithScaling(ctx, options,
+		func(ctx context.Context, replicas int) error {
+			return o.scaleDefaultMachinePool(ctx, client, clusterID, replicas, options.ScaleTimeout)
+		},
+		func(ctx context.Context) error {
+			return o.ocmUpgrade(ctx, client, clusterID, currentVersion, upgradeVersion, options.UpgradeInterval, options.UpgradeTimeout)
+		},
+	)
+}
+
+// upgradeWithScaling performs the upgrade, scaling the default machine pool before and/or after when requested
+func upgradeWithScaling(ctx context.Context, options *UpgradeOptions, scale func(context.Context, int) error, upgrade func(context.Context) error) error {
+	if options.PreUpgradeScale > 0 {
+		if err := scale(ctx, options.PreUpgradeScale); err != nil {
+			return &upgradeError{err: fmt.Errorf("pre upgrade scale failed: %w", err)}
+		}
+	}
+
+	if err := upgrade(ctx); err != nil {
+		return err
+	}
+
+	if options.PostUpgradeScale > 0 {
+		if err := scale(ctx, options.PostUpgradeScale); err != nil {
+			return &upgradeError{err: fmt.Errorf("post upgrade scale failed: %w", err)}
 		}
-		return nil
 	}
 
-	return fmt.Errorf("managed upgrade config does not exist the cluster")
+	return nil
 }
 
-// OCMUpgrade handles the end to end process to upgrade an openshift dedicated cluster
-func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version) error {
+// ocmUpgrade upgrades the cluster with ocm and waits for the managed upgrade operator to finish
+func (o *Provider) ocmUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version, interval, timeout time.Duration) error {
 	var (
-		conditionMessage string
-		dynamicClient    *dynamic.DynamicClient
-		err              error
-		upgradeStatus    string
+		dynamicClient *dynamic.DynamicClient
+		err           error
 	)
 
 	if dynamicClient, err = getKubernetesDynamicClient(client); err != nil {
 		return &upgradeError{err: err}
 	}
 
-	if err = o.addGateAgreement(ctx, clusterID, currentVersion, upgradeVersion); err != nil {
+	if err = o.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		return o.addGateAgreement(ctx, clusterID, currentVersion, upgradeVersion)
+	}); err != nil {
 		return &upgradeError{err: err}
 	}
 
-	if err = o.initiateUpgrade(ctx, clusterID, upgradeVersion.String()); err != nil {
+	if err = o.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		return o.initiateUpgrade(ctx, clusterID, upgradeVersion.String())
+	}); err != nil {
 		return &upgradeError{err: err}
 	}
 
@@ -248,31 +333,41 @@ func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clu
 		return &upgradeError{err: err}
 	}
 
+	return o.waitForUpgrade(ctx, clusterID, upgradeVersion.String(), interval, timeout, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		return getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+	})
+}
+
+// waitForUpgrade polls the managed upgrade operator config until the upgrade to the version finishes, fails, the timeout elapses or the context is cancelled
+func (o *Provider) waitForUpgrade(ctx context.Context, clusterID, upgradeVersion string, interval, timeout time.Duration, getUpgradeConfig func(context.Context) (*unstructured.Unstructured, error)) error {
+	var (
+		conditionMessage string
+		upgradeStatus    string
+	)
+
 	errorHandler := func(key string, found bool, err error) error {
 		if !found || err != nil {
 			o.log.Error(err, "Managed upgrade operator config key is missing", "key", key)
-			time.Sleep(10 * time.Second)
 			return err
 		}
 		return nil
 	}
 
-	for i := 1; i <= upgradeMaxAttempts; i++ {
-		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
-		if err != nil {
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		upgradeConfig, err := getUpgradeConfig(ctx)
+		if err != nil || upgradeConfig == nil {
 			o.log.Error(err, "Failed to get managed upgrade operator config")
-			time.Sleep(upgradeDelay * time.Second)
-			continue
+			return false, nil
 		}
 
 		status, found, err := unstructured.NestedMap(upgradeConfig.Object, "status")
 		if errorHandler("status", found, err) != nil {
-			continue
+			return false, nil
 		}
 
 		histories, found, err := unstructured.NestedSlice(status, "history")
 		if errorHandler("status.history", found, err) != nil {
-			continue
+			return false, nil
 		}
 
 		for _, h := range histories {
@@ -281,7 +376,7 @@ func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clu
 				continue
 			}
 
-			if version == upgradeVersion.String() {
+			if version == upgradeVersion {
 				upgradeStatus, found, err = unstructured.NestedString(h.(map[string]interface{}), "phase")
 				if errorHandler("status.history.[].version.phase", found, err) != nil {
 					continue
@@ -307,23 +402,29 @@ func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clu
 		switch upgradeStatus {
 		case "":
 			o.log.Info("Upgrade has not started yet...", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
-			time.Sleep(upgradeDelay * time.Second)
-		case "Failed", clusterIDLoggerKey, clusterID:
+		case "Failed":
 			o.log.Info("Upgrade failed!", "condition_message", conditionMessage, clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
-			return &upgradeError{err: fmt.Errorf("upgrade failed")}
+			return false, &upgradeError{err: fmt.Errorf("upgrade failed")}
 		case "Upgraded":
 			o.log.Info("Upgrade complete!", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
-			return nil
+			return true, nil
 		case "Pending":
 			o.log.Info("Upgrade is pending...", clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
-			time.Sleep(upgradeDelay * time.Second)
 		case "Upgrading":
 			o.log.Info("Upgrade is in progress", "condition_message", conditionMessage, clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, o.ocmEnvironment)
-			time.Sleep(upgradeDelay * time.Second)
 		}
+
+		return false, nil
+	}, wait.WithContext(ctx), wait.WithInterval(interval), wait.WithTimeout(timeout))
+	if err != nil {
+		var upgradeErr *upgradeError
+		if errors.As(err, &upgradeErr) {
+			return err
+		}
+		return &upgradeError{err: fmt.Errorf("upgrade did not finish: %w", err)}
 	}
 
-	return fmt.Errorf("upgrade is still in progress, failed to finish within max wait attempts")
+	return nil
 }
 
 // getKubernetesDynamicClient returns the kubernetes dynamic client
@@ -0,0 +1,150 @@
+package osd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("upgrade with scaling", func() {
+	var (
+		steps   []string
+		scale   func(context.Context, int) error
+		upgrade func(context.Context) error
+	)
+
+	BeforeEach(func() {
+		steps = nil
+		scale = func(_ context.Context, replicas int) error {
+			steps = append(steps, fmt.Sprintf("scale-%d", replicas))
+			return nil
+		}
+		upgrade = func(context.Context) error {
+			steps = append(steps, "upgrade")
+			return nil
+		}
+	})
+
+	It("should scale before and after the upgrade", func(ctx context.Context) {
+		err := upgradeWithScaling(ctx, &UpgradeOptions{PreUpgradeScale: 6, PostUpgradeScale: 3}, scale, upgrade)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(steps).Should(Equal([]string{"scale-6", "upgrade", "scale-3"}))
+	})
+
+	It("should only upgrade when no scaling is requested", func(ctx context.Context) {
+		err := upgradeWithScaling(ctx, &UpgradeOptions{}, scale, upgrade)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(steps).Should(Equal([]string{"upgrade"}))
+	})
+
+	It("should not upgrade when the pre upgrade scale fails", func(ctx context.Context) {
+		failingScale := func(context.Context, int) error {
+			steps = append(steps, "scale")
+			return errors.New("scale failed")
+		}
+		err := upgradeWithScaling(ctx, &UpgradeOptions{PreUpgradeScale: 6, PostUpgradeScale: 3}, failingScale, upgrade)
+		Expect(err).Should(HaveOccurred())
+		Expect(steps).Should(Equal([]string{"scale"}))
+	})
+})
+
+// upgradeConfig returns a managed upgrade operator config with the version in the phase
+func upgradeConfig(version, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"history": []interface{}{
+				map[string]interface{}{
+					"version": version,
+					"phase":   phase,
+					"conditions": []interface{}{
+						map[string]interface{}{"message": phase},
+					},
+				},
+			},
+		},
+	}}
+}
+
+var _ = Describe("wait for upgrade", func() {
+	var provider *Provider
+
+	BeforeEach(func() {
+		provider = &Provider{log: logr.Discard()}
+	})
+
+	It("should wait until the upgrade completes", func(ctx context.Context) {
+		phases := []string{"", "Pending", "Upgrading", "Upgraded"}
+		calls := 0
+		err := provider.waitForUpgrade(ctx, "123", "4.15.2", time.Millisecond, time.Second, func(context.Context) (*unstructured.Unstructured, error) {
+			phase := phases[calls]
+			calls++
+			return upgradeConfig("4.15.2", phase), nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(Equal(len(phases)))
+	})
+
+	It("should fail when the upgrade fails", func(ctx context.Context) {
+		err := provider.waitForUpgrade(ctx, "123", "4.15.2", time.Millisecond, time.Second, func(context.Context) (*unstructured.Unstructured, error) {
+			return upgradeConfig("4.15.2", "Failed"), nil
+		})
+		Expect(err).Should(MatchError(ContainSubstring("upgrade failed")))
+	})
+
+	DescribeTable("should act on the managed upgrade operator phase",
+		func(phase string, expectedErr string) {
+			err := provider.waitForUpgrade(context.Background(), "123", "4.15.2", time.Millisecond, 20*time.Millisecond, func(context.Context) (*unstructured.Unstructured, error) {
+				return upgradeConfig("4.15.2", phase), nil
+			})
+			if expectedErr == "" {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("not started", "", context.DeadlineExceeded.Error()),
+		Entry("pending", "Pending", context.DeadlineExceeded.Error()),
+		Entry("upgrading", "Upgrading", context.DeadlineExceeded.Error()),
+		Entry("upgraded", "Upgraded", ""),
+		Entry("failed", "Failed", "upgrade failed"),
+		Entry("matching the cluster id", "123", context.DeadlineExceeded.Error()),
+		Entry("matching the cluster id logger key", clusterIDLoggerKey, context.DeadlineExceeded.Error()),
+	)
+
+	It("should stop waiting when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		start := time.Now()
+		err := provider.waitForUpgrade(ctx, "123", "4.15.2", time.Millisecond, time.Hour, func(context.Context) (*unstructured.Unstructured, error) {
+			cancel()
+			return upgradeConfig("4.15.2", "Upgrading"), nil
+		})
+		Expect(err).Should(MatchError(ContainSubstring(context.Canceled.Error())))
+		Expect(time.Since(start)).Should(BeNumerically("<", time.Second))
+	})
+
+	It("should time out when the upgrade does not finish", func(ctx context.Context) {
+		err := provider.waitForUpgrade(ctx, "123", "4.15.2", time.Millisecond, 20*time.Millisecond, func(context.Context) (*unstructured.Unstructured, error) {
+			return upgradeConfig("4.15.2", "Upgrading"), nil
+		})
+		Expect(err).Should(MatchError(ContainSubstring(context.DeadlineExceeded.Error())))
+	})
+})
+
+var _ = Describe("gate agreement", func() {
+	DescribeTable("should only be required for y stream upgrades",
+		func(currentVersion, upgradeVersion string, required bool) {
+			Expect(gateAgreementRequired(*semver.MustParse(currentVersion), *semver.MustParse(upgradeVersion))).Should(Equal(required))
+		},
+		Entry("z stream", "4.15.10", "4.15.12", false),
+		Entry("y stream", "4.15.10", "4.16.0", true),
+		Entry("y stream from the first patch", "4.15.0", "4.16.0", true),
+		Entry("major", "4.18.3", "5.0.0", true),
+	)
+})
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/openshift/osde2e-common/internal/cmd"
 )
@@ -14,8 +16,38 @@ const (
 	commercialRolesCount = 7
 )
 
-// accountRoles represents all roles for a given prefix/version
-type accountRoles struct {
+// defaultAccountRolesPrefixRegex matches the shared account roles prefix with or without the version
+var defaultAccountRolesPrefixRegex = regexp.MustCompile(fmt.Sprintf(`^%s(-\d+\.\d+)?$`, defaultAccountRolesPrefix))
+
+// accountRolesLocks serializes the account roles check and create per prefix,
+// clusters created in parallel commonly share the same prefix
+var accountRolesLocks = &prefixLocks{locks: map[string]*sync.Mutex{}}
+
+// prefixLocks holds a mutex per prefix
+type prefixLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the mutex for the prefix and returns the function to unlock it
+func (p *prefixLocks) lock(prefix string) func() {
+	p.mu.Lock()
+	lock, ok := p.locks[prefix]
+	if !ok {
+		lock = &sync.Mutex{}
+		p.locks[prefix] = lock
+	}
+	p.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// installerRoleSuffixes are the suffixes rosa appends to the prefix when naming installer roles
+var installerRoleSuffixes = []string{"-HCP-ROSA-Installer-Role", "-Installer-Role"}
+
+// AccountRoles represents all roles for a given prefix/version
+type AccountRoles struct {
 	controlPlaneRoleARN string
 	installerRoleARN    string
 	supportRoleARN      string
@@ -25,6 +57,41 @@ type accountRoles struct {
 	hcpWorkerRoleARN    string
 }
 
+// ControlPlaneRoleARN returns the classic control plane role arn
+func (a *AccountRoles) ControlPlaneRoleARN() string {
+	return a.controlPlaneRoleARN
+}
+
+// InstallerRoleARN returns the classic installer role arn
+func (a *AccountRoles) InstallerRoleARN() string {
+	return a.installerRoleARN
+}
+
+// SupportRoleARN returns the classic support role arn
+func (a *AccountRoles) SupportRoleARN() string {
+	return a.supportRoleARN
+}
+
+// WorkerRoleARN returns the classic worker role arn
+func (a *AccountRoles) WorkerRoleARN() string {
+	return a.workerRoleARN
+}
+
+// HCPInstallerRoleARN returns the hosted control plane installer role arn
+func (a *AccountRoles) HCPInstallerRoleARN() string {
+	return a.hcpInstallerRoleARN
+}
+
+// HCPSupportRoleARN returns the hosted control plane support role arn
+func (a *AccountRoles) HCPSupportRoleARN() string {
+	return a.hcpSupportRoleARN
+}
+
+// HCPWorkerRoleARN returns the hosted control plane worker role arn
+func (a *AccountRoles) HCPWorkerRoleARN() string {
+	return a.hcpWorkerRoleARN
+}
+
 // accountRolesError represents the custom error
 type accountRolesError struct {
 	action string
@@ -36,14 +103,22 @@ func (a *accountRolesError) Error() string {
 	return fmt.Sprintf("%s account roles failed: %v", a.action, a.err)
 }
 
+// Unwrap returns the error wrapped by accountRolesError
+func (a *accountRolesError) Unwrap() error {
+	return a.err
+}
+
 // createAccountRoles creates the account roles to be used when creating rosa clusters
-func (r *Provider) CreateAccountRoles(ctx context.Context, prefix, version, channelGroup string) (*accountRoles, error) {
+func (r *Provider) CreateAccountRoles(ctx context.Context, prefix, version, channelGroup string) (*AccountRoles, error) {
 	const action = "create"
 	var (
-		accountRoles *accountRoles
+		accountRoles *AccountRoles
 		err          error
 	)
 
+	unlock := accountRolesLocks.lock(prefix)
+	defer unlock()
+
 	r.log.Info("Checking whether account roles exist", prefixLoggerKey, prefix, versionLoggerKey, version,
 		clusterChannelGroupLoggerKey, channelGroup, ocmEnvironmentLoggerKey, r.ocmEnvironment)
 	if accountRoles, err = r.getAccountRoles(ctx, prefix, version); err != nil {
@@ -65,7 +140,12 @@ func (r *Provider) CreateAccountRoles(ctx context.Context, prefix, version, chan
 
 		// TODO: Open an RFE to rosa to support --output option
 		if _, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...)); err != nil {
-			return nil, &accountRolesError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+			// another process may have created the roles since they were checked
+			if !strings.Contains(fmt.Sprint(stderr), "already exists") {
+				return nil, &accountRolesError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+			}
+			r.log.Info("Account roles were created by another process", prefixLoggerKey, prefix, versionLoggerKey, version,
+				ocmEnvironmentLoggerKey, r.ocmEnvironment)
 		}
 
 		if accountRoles, err = r.getAccountRoles(ctx, prefix, version); err != nil {
@@ -105,11 +185,27 @@ func (r *Provider) DeleteAccountRoles(ctx context.Context, prefix string) error
 	return nil
 }
 
-// getAccountRoles gets the account roles matching the provided prefix and version
-func (r *Provider) getAccountRoles(ctx context.Context, prefix, version string) (*accountRoles, error) {
+// GetAccountRoles returns the account roles matching the prefix
+func (r *Provider) GetAccountRoles(ctx context.Context, prefix string) (*AccountRoles, error) {
+	const action = "get"
+
+	accountRoles, err := r.getAccountRoles(ctx, prefix, "")
+	if err != nil {
+		return nil, &accountRolesError{action: action, err: err}
+	}
+
+	if accountRoles == nil {
+		return nil, &accountRolesError{action: action, err: fmt.Errorf("no account roles exist with prefix %q", prefix)}
+	}
+
+	return accountRoles, nil
+}
+
+// getAccountRoles gets the account roles matching the provided prefix and version, any version matches when unset
+func (r *Provider) getAccountRoles(ctx context.Context, prefix, version string) (*AccountRoles, error) {
 	var (
 		accountRolesFound = 0
-		roles             = &accountRoles{}
+		roles             = &AccountRoles{}
 	)
 
 	commandArgs := []string{
@@ -137,11 +233,11 @@ func (r *Provider) getAccountRoles(ctx context.Context, prefix, version string)
 			continue
 		}
 
-		if version != roleVersion {
+		if version != "" && version != roleVersion {
 			continue
 		}
 
-		if strings.HasPrefix(roleName, "HCP-ROSA") {
+		if strings.HasPrefix(roleName, prefix+"-HCP-ROSA") {
 			switch roleType {
 			case "Installer", "Support", "Worker":
 				accountRolesFound += 1
@@ -188,3 +284,19 @@ func (r *Provider) getAccountRoles(ctx context.Context, prefix, version string)
 		return roles, nil
 	}
 }
+
+// accountRolesPrefixFromRoleARN returns the account roles prefix used to name the installer role
+func accountRolesPrefixFromRoleARN(roleARN string) string {
+	roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+	for _, suffix := range installerRoleSuffixes {
+		if strings.HasSuffix(roleName, suffix) {
+			return strings.TrimSuffix(roleName, suffix)
+		}
+	}
+	return ""
+}
+
+// isDefaultAccountRolesPrefix returns true when the prefix is the shared account roles prefix
+func isDefaultAccountRolesPrefix(prefix string) bool {
+	return defaultAccountRolesPrefixRegex.MatchString(prefix)
+}
@@ -0,0 +1,135 @@
+package rosa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+// accountRolesList returns the rosa list account-roles output for a full set of roles
+func accountRolesList(prefix, version string) string {
+	var roles []string
+	for _, role := range []struct{ name, roleType string }{
+		{"Installer-Role", "Installer"},
+		{"ControlPlane-Role", "Control plane"},
+		{"Support-Role", "Support"},
+		{"Worker-Role", "Worker"},
+		{"HCP-ROSA-Installer-Role", "Installer"},
+		{"HCP-ROSA-Support-Role", "Support"},
+		{"HCP-ROSA-Worker-Role", "Worker"},
+	} {
+		roles = append(roles, `{"RoleName": "`+prefix+`-`+role.name+`", "RoleARN": "arn:aws:iam::123456789012:role/`+prefix+`-`+role.name+`", "RoleType": "`+role.roleType+`", "Version": "`+version+`"}`)
+	}
+	return "[" + strings.Join(roles, ",") + "]"
+}
+
+var _ = Describe("account roles", func() {
+	DescribeTable("should get the prefix from the installer role arn",
+		func(roleARN, expectedPrefix string, shared bool) {
+			prefix := accountRolesPrefixFromRoleARN(roleARN)
+			Expect(prefix).Should(Equal(expectedPrefix))
+			Expect(isDefaultAccountRolesPrefix(prefix)).Should(Equal(shared))
+		},
+		Entry("shared prefix", "arn:aws:iam::123456789012:role/ManagedOpenShift-4.15-Installer-Role", "ManagedOpenShift-4.15", true),
+		Entry("shared hosted control plane prefix", "arn:aws:iam::123456789012:role/ManagedOpenShift-4.15-HCP-ROSA-Installer-Role", "ManagedOpenShift-4.15", true),
+		Entry("unversioned shared prefix", "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role", "ManagedOpenShift", true),
+		Entry("cluster prefix", "arn:aws:iam::123456789012:role/my-cluster-Installer-Role", "my-cluster", false),
+		Entry("cluster prefix containing the shared prefix", "arn:aws:iam::123456789012:role/ManagedOpenShift-test-Installer-Role", "ManagedOpenShift-test", false),
+		Entry("unknown role", "arn:aws:iam::123456789012:role/something-else", "", false),
+	)
+
+	It("should only create the account roles once when created concurrently", func(ctx context.Context) {
+		stateDir := GinkgoT().TempDir()
+		createdFile := filepath.Join(stateDir, "created")
+		rolesFile := filepath.Join(stateDir, "roles.json")
+
+		Expect(os.WriteFile(rolesFile, []byte(accountRolesList("shared", "4.15")), 0o644)).Should(Succeed())
+
+		// fake rosa cli listing the roles only once they have been created
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		script := `#!/bin/sh
+if [ "$1" = "create" ]; then
+  echo created >> ` + createdFile + `
+  exit 0
+fi
+if [ -f ` + createdFile + ` ]; then
+  cat ` + rolesFile + `
+else
+  echo '[]'
+fi
+`
+		Expect(os.WriteFile(rosaBinary, []byte(script), 0o755)).Should(Succeed())
+
+		provider := &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := provider.CreateAccountRoles(ctx, "shared", "4.15", "stable")
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		created, err := os.ReadFile(createdFile)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(strings.Count(string(created), "created")).Should(Equal(1))
+	})
+
+	Describe("get", func() {
+		var provider *Provider
+
+		BeforeEach(func() {
+			rolesFile := filepath.Join(GinkgoT().TempDir(), "roles.json")
+			Expect(os.WriteFile(rolesFile, []byte(accountRolesList("shared", "4.15")), 0o644)).Should(Succeed())
+
+			rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+			Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\ncat "+rolesFile+"\n"), 0o755)).Should(Succeed())
+
+			provider = &Provider{
+				awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+				log:            logr.Discard(),
+				rosaBinary:     rosaBinary,
+			}
+		})
+
+		It("should return every role arn for the prefix", func(ctx context.Context) {
+			roles, err := provider.GetAccountRoles(ctx, "shared")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			const arn = "arn:aws:iam::123456789012:role/shared-"
+			Expect(roles.InstallerRoleARN()).Should(Equal(arn + "Installer-Role"))
+			Expect(roles.ControlPlaneRoleARN()).Should(Equal(arn + "ControlPlane-Role"))
+			Expect(roles.SupportRoleARN()).Should(Equal(arn + "Support-Role"))
+			Expect(roles.WorkerRoleARN()).Should(Equal(arn + "Worker-Role"))
+			Expect(roles.HCPInstallerRoleARN()).Should(Equal(arn + "HCP-ROSA-Installer-Role"))
+			Expect(roles.HCPSupportRoleARN()).Should(Equal(arn + "HCP-ROSA-Support-Role"))
+			Expect(roles.HCPWorkerRoleARN()).Should(Equal(arn + "HCP-ROSA-Worker-Role"))
+		})
+
+		It("should fail when no roles exist for the prefix", func(ctx context.Context) {
+			_, err := provider.GetAccountRoles(ctx, "missing")
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type        = string
+  description = "The region to list the availability zones of"
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+# only data sources, applying this config never creates resources
+data "aws_availability_zones" "available" {
+  state = "available"
+}
+
+output "availability-zones" {
+  value = data.aws_availability_zones.available.names
+}
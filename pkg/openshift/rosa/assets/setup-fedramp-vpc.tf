@@ -46,6 +46,18 @@ variable "multi_az" {
   description = "Setup a multi-AZ VPC for the cluster"
 }
 
+variable "az_count" {
+  type        = number
+  default     = 0
+  description = "The number of availability zones the VPC spans, derived from multi_az when unset"
+}
+
+variable "create_public_subnets" {
+  type        = bool
+  default     = true
+  description = "Create public subnets and NAT gateways, disable for fully private clusters"
+}
+
 variable "create_elb_iam_role" {
   type        = bool
   default     = true
@@ -58,11 +70,10 @@ data "aws_availability_zones" "available" {
 }
 
 locals {
-  azs = var.multi_az ? [
-    data.aws_availability_zones.available.names[0],
-    data.aws_availability_zones.available.names[1],
-    data.aws_availability_zones.available.names[2],
-  ] : [data.aws_availability_zones.available.names[0]]
+  az_count = var.az_count > 0 ? var.az_count : (var.multi_az ? 3 : 1)
+  azs      = slice(data.aws_availability_zones.available.names, 0, local.az_count)
+  # the vpc cidr is split evenly between a private and optional public subnet per az
+  subnet_newbits = ceil(log(length(local.azs) * (var.create_public_subnets ? 2 : 1), 2))
 }
 
 # main.tf
@@ -89,7 +100,7 @@ resource "aws_subnet" "rosa_private" {
   for_each          = { for idx, az in local.azs : az => idx }
   availability_zone = each.key
   vpc_id            = aws_vpc.rosa.id
-  cidr_block        = cidrsubnet(var.cidr, length(local.azs), each.value)
+  cidr_block        = cidrsubnet(var.cidr, local.subnet_newbits, each.value)
 
   tags = {
     Name                              = "${var.cluster_name}-private-${each.key}"
@@ -102,10 +113,10 @@ resource "aws_subnet" "rosa_private" {
 }
 
 resource "aws_subnet" "rosa_public" {
-  for_each          = { for idx, az in local.azs : az => idx }
+  for_each          = var.create_public_subnets ? { for idx, az in local.azs : az => idx } : {}
   availability_zone = each.key
   vpc_id            = aws_vpc.rosa.id
-  cidr_block        = cidrsubnet(var.cidr, length(local.azs), length(local.azs) + each.value)
+  cidr_block        = cidrsubnet(var.cidr, local.subnet_newbits, length(local.azs) + each.value)
 
   tags = {
     Name                     = "${var.cluster_name}-public-${each.key}"
@@ -118,13 +129,16 @@ resource "aws_subnet" "rosa_public" {
 }
 
 resource "aws_internet_gateway" "rosa" {
+  count = var.create_public_subnets ? 1 : 0
+
   tags = {
     Name = "${var.cluster_name}-igw"
   }
 }
 
 resource "aws_internet_gateway_attachment" "rosa" {
-  internet_gateway_id = aws_internet_gateway.rosa.id
+  count               = var.create_public_subnets ? 1 : 0
+  internet_gateway_id = aws_internet_gateway.rosa[0].id
   vpc_id              = aws_vpc.rosa.id
 }
 
@@ -143,9 +157,10 @@ resource "aws_route_table_association" "rosa_public" {
 }
 
 resource "aws_route" "internet_egress" {
+  count                  = var.create_public_subnets ? 1 : 0
   route_table_id         = aws_route_table.rosa_public.id
   destination_cidr_block = "0.0.0.0/0"
-  gateway_id             = aws_internet_gateway.rosa.id
+  gateway_id             = aws_internet_gateway.rosa[0].id
 }
 
 resource "aws_eip" "nat_gw" {
@@ -189,14 +204,23 @@ resource "aws_route_table_association" "rosa_private" {
 }
 
 resource "aws_route" "nat_gateway" {
-  for_each               = aws_subnet.rosa_private
+  for_each               = var.create_public_subnets ? aws_subnet.rosa_private : {}
   route_table_id         = aws_route_table.rosa_private[each.key].id
   destination_cidr_block = "0.0.0.0/0"
   nat_gateway_id         = aws_nat_gateway.rosa[each.key].id
 }
 
 # outputs.tf
-output "cluster-private-subnet" {
-  description = "Private subnet ID"
-  value       = values(aws_subnet.rosa_private)[0].id
-}
\ No newline at end of file
+output "vpc-id" {
+  value = aws_vpc.rosa.id
+}
+
+output "private-subnets" {
+  description = "Private subnet IDs"
+  value       = [for subnet in values(aws_subnet.rosa_private) : subnet.id]
+}
+
+output "public-subnets" {
+  description = "Public subnet IDs"
+  value       = [for subnet in values(aws_subnet.rosa_public) : subnet.id]
+}
@@ -17,22 +17,22 @@ variable "aws_region" {
   }
 }
 
-variable "az_ids" {
-  type = object({
-    eu-central-1 = list(string)
-    eu-west-1 = list(string)
-    us-east-1 = list(string)
-    us-east-2 = list(string)
-    us-west-2 = list(string)
-  })
-  description = "A list of region-mapped AZ IDs that a subnet should get deployed into"
-  default = {
-    eu-central-1 = ["euc1-az1", "euc1-az2"]
-    eu-west-1    = ["euw1-az1", "euw1-az2"]
-    us-east-1    = ["use1-az1", "use1-az2"]
-    us-east-2    = ["use2-az1", "use2-az2"]
-    us-west-2    = ["usw2-az1", "usw2-az2"]
-  }
+variable "az_count" {
+  type        = number
+  default     = 1
+  description = "The number of availability zones the VPC spans"
+}
+
+variable "create_public_subnets" {
+  type        = bool
+  default     = true
+  description = "Create public subnets and a NAT gateway, disable for fully private clusters"
+}
+
+variable "cidr" {
+  type        = string
+  default     = "10.0.0.0/16"
+  description = "ROSA cluster VPC CIDR, it must match the clusters machine CIDR"
 }
 
 variable "cluster_name" {
@@ -46,6 +46,16 @@ variable "cluster_name" {
   }
 }
 
+data "aws_availability_zones" "available" {
+  state = "available"
+}
+
+locals {
+  azs = slice(data.aws_availability_zones.available.names, 0, var.az_count)
+  # the vpc cidr is split evenly between a private and optional public subnet per az
+  subnet_newbits = ceil(log(length(local.azs) * (var.create_public_subnets ? 2 : 1), 2))
+}
+
 provider "aws" {
   region = var.aws_region
 }
@@ -55,27 +65,27 @@ module "vpc" {
   version = "~> 4.0.0"
 
   name = "${var.cluster_name}-vpc"
-  cidr = "10.0.0.0/16"
+  cidr = var.cidr
 
-  azs             = var.az_ids[var.aws_region]
-  private_subnets = ["10.0.1.0/24", "10.0.2.0/24"]
-  public_subnets  = ["10.0.101.0/24", "10.0.102.0/24"]
+  azs             = local.azs
+  private_subnets = [for index, az in local.azs : cidrsubnet(var.cidr, local.subnet_newbits, index)]
+  public_subnets  = var.create_public_subnets ? [for index, az in local.azs : cidrsubnet(var.cidr, local.subnet_newbits, length(local.azs) + index)] : []
 
-  enable_nat_gateway            = true
+  enable_nat_gateway            = var.create_public_subnets
   single_nat_gateway            = true
   enable_dns_hostnames          = true
   enable_dns_support            = true
   manage_default_security_group = false
 }
 
-output "cluster-private-subnet" {
-  value = module.vpc.private_subnets[0]
+output "vpc-id" {
+  value = module.vpc.vpc_id
 }
 
-output "cluster-public-subnet" {
-  value = module.vpc.public_subnets[0]
+output "private-subnets" {
+  value = module.vpc.private_subnets
 }
 
-output "node-private-subnet" {
-  value = module.vpc.private_subnets[1]
-}
\ No newline at end of file
+output "public-subnets" {
+  value = module.vpc.public_subnets
+}
@@ -0,0 +1,123 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+const (
+	// BinaryEnv is the environment variable setting the path of the rosa cli to
+	// use, the cli is neither looked up nor downloaded when set
+	BinaryEnv = "ROSA_BINARY"
+	// CacheDirEnv is the environment variable setting the directory the rosa
+	// cli is downloaded to, defaults to the systems temp directory. Downloaded
+	// versions are shared from it, the latest version until it is older than
+	// latestCLIMaxAge
+	CacheDirEnv = "ROSA_CACHE_DIR"
+	// CLIVersionEnv is the environment variable pinning the rosa cli version to
+	// download, the rosa cli on the path is ignored when set
+	CLIVersionEnv = "ROSA_CLI_VERSION"
+
+	// latestCLIVersion downloads the newest rosa cli
+	latestCLIVersion = "latest"
+	// latestCLIMaxAge bounds reusing the cached latest rosa cli, it is
+	// downloaded again once older to pick up new releases
+	latestCLIMaxAge = 6 * time.Hour
+
+	// cacheLockTimeout bounds waiting on another process downloading the rosa
+	// cli, locks older than it are considered abandoned
+	cacheLockTimeout = downloadTimeout * (downloadRetryMax + 1)
+)
+
+// cachedCLI returns the rosa cli cached in the directory, downloading it when
+// missing or, for the latest version, stale. A lock file serializes processes
+// sharing the cache and the download is renamed into place so the cached cli is
+// never partially written
+func cachedCLI(ctx context.Context, client *retryablehttp.Client, url, cacheDir, version string) (string, bool, error) {
+	rosaFilename := filepath.Join(cacheDir, fmt.Sprintf("rosa-%s", version))
+
+	if cachedCLIUsable(rosaFilename, version) {
+		return rosaFilename, false, nil
+	}
+
+	unlock, err := lockFile(ctx, rosaFilename+".lock", cacheLockTimeout)
+	if err != nil {
+		return "", false, err
+	}
+	defer unlock()
+
+	// another process may have downloaded it while waiting for the lock
+	if cachedCLIUsable(rosaFilename, version) {
+		return rosaFilename, false, nil
+	}
+
+	downloadDir, err := os.MkdirTemp(cacheDir, "rosa-download-")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create rosa download directory: %v", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(downloadDir)
+	}()
+
+	downloadFilename := filepath.Join(downloadDir, "rosa")
+	if err = downloadCLI(client, url, downloadFilename, filepath.Join(downloadDir, "rosa.tar.gz")); err != nil {
+		return "", false, err
+	}
+
+	if err = os.Rename(downloadFilename, rosaFilename); err != nil {
+		return "", false, fmt.Errorf("failed to move rosa cli to %s: %v", rosaFilename, err)
+	}
+
+	return rosaFilename, true, nil
+}
+
+// cachedCLIUsable returns true when the cached rosa cli exists and, for the
+// latest version, is younger than latestCLIMaxAge
+func cachedCLIUsable(rosaFilename, version string) bool {
+	info, err := os.Stat(rosaFilename)
+	if err != nil {
+		return false
+	}
+	return version != latestCLIVersion || time.Since(info.ModTime()) < latestCLIMaxAge
+}
+
+// lockFile creates the lock file, waiting while another process holds it. The
+// returned function releases the lock
+func lockFile(ctx context.Context, filename string, timeout time.Duration) (func(), error) {
+	acquire := func(context.Context) (bool, error) {
+		file, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			return true, file.Close()
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return false, fmt.Errorf("failed to create lock %s: %v", filename, err)
+		}
+
+		// remove locks abandoned by processes that exited while holding them
+		if info, err := os.Stat(filename); err == nil && time.Since(info.ModTime()) > timeout {
+			_ = os.Remove(filename)
+		}
+
+		return false, nil
+	}
+
+	acquired, err := acquire(ctx)
+	if err == nil && !acquired {
+		err = poll.Until(ctx, poll.Strategy{Interval: time.Second}, timeout, acquire)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", filename, err)
+	}
+
+	return func() {
+		_ = os.Remove(filename)
+	}, nil
+}
@@ -0,0 +1,183 @@
+package rosa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-retryablehttp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rosa cli cache", func() {
+	var (
+		client    *retryablehttp.Client
+		cacheDir  string
+		url       string
+		downloads atomic.Int32
+	)
+
+	BeforeEach(func() {
+		client = newDownloadClient()
+		client.RetryMax = 0
+		client.Logger = nil
+
+		cacheDir = GinkgoT().TempDir()
+
+		downloads.Store(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			downloads.Add(1)
+			w.Header().Set("Content-Type", "application/x-gzip")
+			_, _ = w.Write(rosaTarball("#!/bin/sh"))
+		}))
+		DeferCleanup(server.Close)
+		url = server.URL
+	})
+
+	It("should download once and reuse the cached cli", func(ctx context.Context) {
+		rosaBinary, downloaded, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeTrue())
+		Expect(rosaBinary).Should(Equal(filepath.Join(cacheDir, "rosa-1.2.40")))
+
+		rosaBinary, downloaded, err = cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeFalse())
+		Expect(rosaBinary).Should(BeAnExistingFile())
+		Expect(downloads.Load()).Should(BeEquivalentTo(1))
+	})
+
+	It("should keep versions side by side", func(ctx context.Context) {
+		first, _, err := cachedCLI(ctx, client, url, cacheDir, "1.2.39")
+		Expect(err).ShouldNot(HaveOccurred())
+		second, _, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(first).ShouldNot(Equal(second))
+		Expect(first).Should(BeAnExistingFile())
+		Expect(second).Should(BeAnExistingFile())
+	})
+
+	It("should share one download between concurrent callers", func(ctx context.Context) {
+		var (
+			wg         sync.WaitGroup
+			downloaded atomic.Int32
+		)
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				rosaBinary, ok, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(rosaBinary).Should(BeAnExistingFile())
+				if ok {
+					downloaded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		Expect(downloaded.Load()).Should(BeEquivalentTo(1))
+		Expect(downloads.Load()).Should(BeEquivalentTo(1))
+		Expect(filepath.Join(cacheDir, "rosa-1.2.40.lock")).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should reuse the latest version until it is stale", func(ctx context.Context) {
+		first, downloaded, err := cachedCLI(ctx, client, url, cacheDir, "latest")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeTrue())
+		Expect(first).Should(Equal(filepath.Join(cacheDir, "rosa-latest")))
+
+		second, downloaded, err := cachedCLI(ctx, client, url, cacheDir, "latest")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeFalse())
+		Expect(second).Should(Equal(first))
+		Expect(downloads.Load()).Should(BeEquivalentTo(1))
+
+		stale := time.Now().Add(-2 * latestCLIMaxAge)
+		Expect(os.Chtimes(first, stale, stale)).Should(Succeed())
+
+		_, downloaded, err = cachedCLI(ctx, client, url, cacheDir, "latest")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeTrue())
+		Expect(downloads.Load()).Should(BeEquivalentTo(2))
+	})
+
+	It("should not expire pinned versions", func(ctx context.Context) {
+		rosaBinary, _, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+		old := time.Now().Add(-2 * latestCLIMaxAge)
+		Expect(os.Chtimes(rosaBinary, old, old)).Should(Succeed())
+
+		_, downloaded, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeFalse())
+		Expect(downloads.Load()).Should(BeEquivalentTo(1))
+	})
+
+	It("should remove abandoned locks", func(ctx context.Context) {
+		lock := filepath.Join(cacheDir, "rosa-1.2.40.lock")
+		Expect(os.WriteFile(lock, nil, 0o644)).Should(Succeed())
+		abandoned := time.Now().Add(-2 * cacheLockTimeout)
+		Expect(os.Chtimes(lock, abandoned, abandoned)).Should(Succeed())
+
+		_, downloaded, err := cachedCLI(ctx, client, url, cacheDir, "1.2.40")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(downloaded).Should(BeTrue())
+	})
+
+	It("should only uninstall the cli downloaded by the provider", func(ctx context.Context) {
+		rosaBinary := filepath.Join(cacheDir, "rosa-latest")
+		Expect(os.WriteFile(rosaBinary, nil, 0o755)).Should(Succeed())
+
+		provider := &Provider{rosaBinary: rosaBinary, log: logr.Discard()}
+		Expect(provider.Uninstall(ctx)).Should(Succeed())
+		Expect(rosaBinary).Should(BeAnExistingFile())
+
+		provider.downloadedRosaBinary = true
+		Expect(provider.Uninstall(ctx)).Should(Succeed())
+		Expect(rosaBinary).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should remove the downloaded cli when the provider is closed", func(ctx context.Context) {
+		rosaBinary := filepath.Join(cacheDir, "rosa-latest")
+		Expect(os.WriteFile(rosaBinary, nil, 0o755)).Should(Succeed())
+
+		provider := &Provider{rosaBinary: rosaBinary, downloadedRosaBinary: true, log: logr.Discard()}
+		Expect(provider.Close(ctx)).Should(Succeed())
+		Expect(rosaBinary).ShouldNot(BeAnExistingFile())
+
+		// closing again is a no-op
+		Expect(provider.Close(ctx)).Should(Succeed())
+	})
+})
+
+var _ = Describe("rosa cli binary", func() {
+	It("should use the binary from the environment without downloading", func(ctx context.Context) {
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\necho '1.2.40'\n"), 0o755)).Should(Succeed())
+		GinkgoT().Setenv(BinaryEnv, rosaBinary)
+
+		path, downloaded, err := cliCheck(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(path).Should(Equal(rosaBinary))
+		Expect(downloaded).Should(BeFalse())
+
+		version, err := getVersion(ctx, path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version).Should(Equal("1.2.40"))
+	})
+
+	It("should fail to get the version of a binary that does not run", func(ctx context.Context) {
+		_, err := getVersion(ctx, filepath.Join(GinkgoT().TempDir(), "rosa"))
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -2,22 +2,58 @@ package rosa
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
-	"github.com/openshift/osde2e-common/internal/cmd"
+	"github.com/openshift/osde2e-common/internal/mask"
 	"github.com/openshift/osde2e-common/pkg/clients/ocm"
 	openshiftclient "github.com/openshift/osde2e-common/pkg/clients/openshift"
-	"sigs.k8s.io/e2e-framework/klient/wait"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-const defaultAccountRolesPrefix = "ManagedOpenShift"
+const (
+	defaultAccountRolesPrefix  = "ManagedOpenShift"
+	defaultClientRetryAttempts = 3
+	defaultMachineCIDR         = "10.0.0.0/16"
+
+	// worker root volume limits in GiB, hosted control plane clusters allow smaller volumes
+	minWorkerDiskSize         = 128
+	minHostedCPWorkerDiskSize = 75
+	maxWorkerDiskSize         = 16384
+)
+
+// workerDiskSizePattern matches disk sizes in GiB or TiB, sizes without a unit are GiB
+var workerDiskSizePattern = regexp.MustCompile(`^(\d+)\s*(GiB|TiB)?$`)
+
+// Visibility represents who can reach a cluster endpoint
+type Visibility string
+
+const (
+	// VisibilityExternal endpoints are reachable from the internet
+	VisibilityExternal Visibility = "external"
+	// VisibilityInternal endpoints are only reachable from within the vpc
+	VisibilityInternal Visibility = "internal"
+)
+
+// EC2MetadataHTTPTokens represents whether the cluster instances require imdsv2
+type EC2MetadataHTTPTokens string
+
+const (
+	// EC2MetadataHTTPTokensOptional allows both imdsv1 and imdsv2
+	EC2MetadataHTTPTokensOptional EC2MetadataHTTPTokens = "optional"
+	// EC2MetadataHTTPTokensRequired enforces imdsv2
+	EC2MetadataHTTPTokensRequired EC2MetadataHTTPTokens = "required"
+)
 
 // CreateClusterOptions represents data used to create clusters
 type CreateClusterOptions struct {
@@ -31,14 +67,42 @@ type CreateClusterOptions struct {
 	UseDefaultAccountRolesPrefix bool
 	EnableAutoscaling            bool
 	ETCDEncryption               bool
+	// DisableWorkloadMonitoring disables user workload monitoring, which rosa
+	// enables by default, it only applies at install time
+	DisableWorkloadMonitoring bool
+	// ExternalOIDC uses the OidcConfigID and OperatorRolesPrefix as is, they
+	// are managed outside of the provider and are not created or deleted by it
+	ExternalOIDC bool
+	// Private restricts the api and default ingress to private connectivity
+	// without private link. It requires SubnetIDs and can not be combined with
+	// PrivateLink or an external api or ingress visibility. It emits the same
+	// --private flag as an internal APIVisibility without PrivateLink, the
+	// validation only differs in rejecting those combinations
+	Private bool
+	// VPCPrivateSubnetsOnly creates the vpc without public subnets or nat
+	// gateways, used for fully private clusters
+	VPCPrivateSubnetsOnly bool
+	// DryRun validates the options and sets CreateClusterResult.Command to the
+	// rosa create cluster command without running it or creating any aws or ocm
+	// resources. Account roles, the oidc config and subnets the provider would
+	// create are placeholders
+	DryRun bool
+	// ReuseExistingCluster returns the cluster with the cluster name when it
+	// already exists instead of failing with ErrClusterExists, the cluster is
+	// still waited on to be installed and healthy
+	ReuseExistingCluster bool
 
 	HostPrefix  int
 	Replicas    int
 	MinReplicas int
 	MaxReplicas int
+	// VPCAvailabilityZones is the number of availability zones the created vpc
+	// spans, defaults to 3 for multi az clusters and 1 otherwise
+	VPCAvailabilityZones int
 
 	ArtifactDir               string
 	AdditionalTrustBundleFile string
+	BaseDomain                string
 	ChannelGroup              string
 	ClusterName               string
 	ComputeMachineType        string
@@ -49,19 +113,82 @@ type CreateClusterOptions struct {
 	NetworkType               string
 	NoProxy                   string
 	OidcConfigID              string
+	OidcIssuerURL             string
+	OidcSecretARN             string
+	OperatorRolesPrefix       string
 	PodCIDR                   string
 	ServiceCIDR               string
 	SubnetIDs                 string
 	Version                   string
-	WorkingDir                string
-
-	accountRoles accountRoles
+	// WorkingDir holds the terraform state of the vpcs created for clusters,
+	// each in a <cluster name>-vpc directory. Defaults to the temp directory
+	WorkingDir string
+	// WorkerDiskSize is the root volume size of the workers, e.g. "300GiB",
+	// "1TiB" or "300" for GiB. Defaults to the rosa default when unset
+	WorkerDiskSize string
+
+	// APIVisibility and IngressVisibility set the listening mode of the api
+	// server and default ingress, both are external when undefined
+	APIVisibility     Visibility
+	IngressVisibility Visibility
+
+	// EC2MetadataHTTPTokens sets whether the instance metadata service requires
+	// session tokens (imdsv2), the rosa default is used when undefined
+	EC2MetadataHTTPTokens EC2MetadataHTTPTokens
+
+	// AdditionalComputeSecurityGroupIDs, AdditionalInfraSecurityGroupIDs and
+	// AdditionalControlPlaneSecurityGroupIDs attach existing security groups to
+	// the nodes. They require SubnetIDs and must belong to the subnets vpc,
+	// hosted control plane clusters only support compute security groups
+	AdditionalComputeSecurityGroupIDs      []string
+	AdditionalInfraSecurityGroupIDs        []string
+	AdditionalControlPlaneSecurityGroupIDs []string
+
+	accountRoles AccountRoles
 
 	Properties map[string]string
 
 	InstallTimeout     time.Duration
 	HealthCheckTimeout time.Duration
 	ExpirationDuration time.Duration
+	// ClientRetryAttempts is the number of attempts to construct the cluster
+	// client once installed, defaults to 3
+	ClientRetryAttempts int
+	// OperatorRolesTrustPolicyTimeout opts in to waiting for the trust policies
+	// of pre-created operator roles (OperatorRolesPrefix) to reference the oidc
+	// config before the cluster is created
+	OperatorRolesTrustPolicyTimeout time.Duration
+}
+
+// vpcLayout returns the network of the vpc to create for the cluster
+func (o *CreateClusterOptions) vpcLayout() vpcLayout {
+	layout := vpcLayout{
+		cidr:              o.MachineCidr,
+		availabilityZones: o.VPCAvailabilityZones,
+		publicSubnets:     !o.VPCPrivateSubnetsOnly,
+	}
+
+	if layout.availabilityZones == 0 {
+		layout.availabilityZones = 1
+		if o.MultiAZ {
+			layout.availabilityZones = defaultMultiAZAvailableZones
+		}
+	}
+
+	return layout
+}
+
+// MarshalLog returns the options to log with the credentials of the proxy urls
+// masked
+func (o *CreateClusterOptions) MarshalLog() any {
+	// a distinct type so the logger does not invoke MarshalLog again
+	type createClusterOptions CreateClusterOptions
+
+	masked := createClusterOptions(*o)
+	masked.HTTPProxy = mask.URLCredentials(o.HTTPProxy)
+	masked.HTTPSProxy = mask.URLCredentials(o.HTTPSProxy)
+
+	return masked
 }
 
 // DeleteClusterOptions represents data used to delete clusters
@@ -74,14 +201,35 @@ type DeleteClusterOptions struct {
 
 	DeleteHostedVPC    bool
 	DeleteOidcConfigID bool
-	HostedCP           bool
-	STS                bool
-	MintMode           bool
-	PrivateLink        bool
+	// ExternalOIDC skips deleting the oidc config, its provider and the operator roles
+	ExternalOIDC bool
+	HostedCP     bool
+	STS          bool
+	MintMode     bool
+	PrivateLink  bool
+	// SkipAccountRoleDeletion and SkipOIDCDeletion keep iam resources that are
+	// shared with other clusters in place
+	SkipAccountRoleDeletion bool
+	SkipOIDCDeletion        bool
 
 	UninstallTimeout time.Duration
 }
 
+var (
+	// ErrClusterNameRequired is returned when the create cluster options have no cluster name
+	ErrClusterNameRequired = errors.New("cluster name is required")
+	// ErrClusterVersionRequired is returned when the create cluster options have no version
+	ErrClusterVersionRequired = errors.New("cluster version is required")
+	// ErrClusterNotFound is returned when no cluster matches the name or id in ocm
+	ErrClusterNotFound = errors.New("cluster not found")
+	// ErrClusterExists is returned when creating a cluster whose name is already
+	// used in ocm and ReuseExistingCluster is unset
+	ErrClusterExists = errors.New("cluster already exists")
+	// ErrQuotaExceeded is returned when the cluster cannot be created due to
+	// exhausted ocm or aws quota
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
 // clusterError represents the custom error
 type clusterError struct {
 	action string
@@ -93,16 +241,75 @@ func (c *clusterError) Error() string {
 	return fmt.Sprintf("%s cluster failed: %v", c.action, c.err)
 }
 
-// CreateCluster creates a rosa cluster using the provided inputs
+// Unwrap returns the error wrapped by clusterError
+func (c *clusterError) Unwrap() error {
+	return c.err
+}
+
+// CreateClusterResult represents the resources created with a cluster
+type CreateClusterResult struct {
+	ClusterID string
+	// Network is the vpc created for the cluster, nil when the cluster was
+	// installed into existing subnets
+	Network *Network
+	// Command is the rosa create cluster command, only set for dry runs
+	Command []string
+}
+
+// CreateCluster creates a rosa cluster using the provided inputs. Dry runs
+// return no cluster id, use CreateClusterWithResult for their command
 func (r *Provider) CreateCluster(ctx context.Context, options *CreateClusterOptions) (string, error) {
+	result, err := r.CreateClusterWithResult(ctx, options)
+	if result == nil {
+		return "", err
+	}
+	return result.ClusterID, err
+}
+
+// CreateClusterWithResult creates a rosa cluster using the provided inputs and
+// returns the resources created with it
+func (r *Provider) CreateClusterWithResult(ctx context.Context, options *CreateClusterOptions) (*CreateClusterResult, error) {
 	const action = "create"
 
+	r = r.withOperationID()
+
+	if options.DryRun {
+		// the dry run fills in placeholders, keep them off the callers options
+		// so they can be reused to create the cluster
+		dryRunOptions := *options
+		options = &dryRunOptions
+	}
+
 	options.setDefaultCreateClusterOptions()
 
+	r.log.Info("Creating cluster", clusterNameLoggerKey, options.ClusterName, "options", options)
+
+	if err := validateSecurityGroups(options); err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	if options.DryRun {
+		command, err := r.createClusterDryRun(options)
+		if err != nil {
+			return nil, &clusterError{action: action, err: err}
+		}
+		return &CreateClusterResult{Command: command}, nil
+	}
+
+	// check before setting up any resources, a retried job would otherwise
+	// create them only to fail on the duplicate cluster name
+	existingClusterID, err := r.existingCluster(ctx, options.ClusterName, options.ReuseExistingCluster, r.findCluster)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+	if existingClusterID != "" {
+		return r.waitForCreatedCluster(ctx, &CreateClusterResult{ClusterID: existingClusterID}, options)
+	}
+
 	if options.ChannelGroup == "nightly" {
 		// TODO: validate version is as expected
 		r.log.Info("Waiting up to 5 minutes for nightly version to be available", "version", options.Version)
-		if err := wait.For(func(ctx context.Context) (bool, error) {
+		if err := r.waitUntil(ctx, 5*time.Second, 5*time.Minute, func(ctx context.Context) (bool, error) {
 			versions, err := r.Versions(ctx, options.ChannelGroup, options.HostedCP)
 			if err != nil {
 				return false, err
@@ -115,19 +322,29 @@ func (r *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 			}
 			return false, nil
 		}); err != nil {
-			return "", &clusterError{action: action, err: err}
+			return nil, &clusterError{action: action, err: err}
 		}
 	}
 
-	err := r.regionCheck(ctx, r.awsCredentials.Region, options.HostedCP, options.MultiAZ)
+	err = r.regionCheck(ctx, r.awsCredentials.Region, options.HostedCP, options.MultiAZ)
 	if err != nil {
-		return "", &clusterError{action: action, err: err}
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	if options.BaseDomain != "" {
+		if err = r.dnsDomainCheck(ctx, options.BaseDomain); err != nil {
+			return nil, &clusterError{action: action, err: err}
+		}
+	}
+
+	if err = r.securityGroupsCheck(ctx, options); err != nil {
+		return nil, &clusterError{action: action, err: err}
 	}
 
 	if options.HostedCP || options.STS {
 		version, err := semver.NewVersion(options.Version)
 		if err != nil {
-			return "", &clusterError{action: action, err: fmt.Errorf("failed to parse version (%q) into semantic version: %v", options.Version, err)}
+			return nil, &clusterError{action: action, err: fmt.Errorf("failed to parse version (%q) into semantic version: %v", options.Version, err)}
 		}
 		majorMinor := fmt.Sprintf("%d.%d", version.Major(), version.Minor())
 
@@ -138,58 +355,102 @@ func (r *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 
 		accountRoles, err := r.CreateAccountRoles(ctx, accountRolesPrefix, majorMinor, options.ChannelGroup)
 		if err != nil {
-			return "", &clusterError{action: action, err: err}
+			return nil, &clusterError{action: action, err: err}
 		}
 		options.accountRoles = *accountRoles
 
-		if options.OidcConfigID == "" {
+		if options.OidcConfigID == "" && !options.ExternalOIDC {
 			options.OidcConfigID, err = r.CreateOIDCConfig(
 				ctx,
 				options.ClusterName,
 				options.accountRoles.installerRoleARN,
+				&OIDCConfigOptions{IssuerURL: options.OidcIssuerURL, SecretARN: options.OidcSecretARN},
 			)
 			if err != nil {
-				return "", &clusterError{action: action, err: err}
+				return nil, &clusterError{action: action, err: err}
 			}
 		}
 	}
 
+	resources := clusterResources{externalOIDC: options.ExternalOIDC}
+
 	if options.HostedCP || options.PrivateLink {
 		if options.SubnetIDs == "" {
-			vpc, err := r.createVPC(
+			network, err := r.createVPC(
 				ctx,
 				options.ClusterName,
 				r.awsCredentials.Region,
 				options.WorkingDir,
+				options.vpcLayout(),
 				options.HostedCP,
 				options.PrivateLink,
 			)
 			if err != nil {
-				return "", &clusterError{action: action, err: err}
+				return nil, &clusterError{action: action, err: err}
 			}
-			options.SubnetIDs = fmt.Sprintf("%s,%s", vpc.privateSubnet, vpc.publicSubnet)
+			options.SubnetIDs = strings.Join(network.subnetIDs(options.PrivateLink), ",")
+
+			// record the vpc so it is deleted even when the cluster fails to create
+			resources.network = network
+			r.createdResources.add(options.ClusterName, resources)
+		}
+	}
+
+	if options.OperatorRolesTrustPolicyTimeout > 0 && options.OperatorRolesPrefix != "" && options.OidcConfigID != "" {
+		err = r.WaitForOperatorRolesTrustPolicy(ctx, options.OperatorRolesPrefix, options.OidcConfigID, options.OperatorRolesTrustPolicyTimeout)
+		if err != nil {
+			return nil, &clusterError{action: action, err: err}
 		}
 	}
 
 	clusterID, err := r.createCluster(ctx, options)
 	if err != nil {
-		return "", &clusterError{action: action, err: err}
+		return nil, &clusterError{action: action, err: err}
 	}
 
-	err = r.waitForClusterToBeInstalled(ctx, clusterID, options.ClusterName, options.ArtifactDir, options.InstallTimeout)
+	r.createdResources.add(options.ClusterName, resources)
+
+	return r.waitForCreatedCluster(ctx, &CreateClusterResult{ClusterID: clusterID, Network: resources.network}, options)
+}
+
+// existingCluster returns the id of the cluster with the name when it exists and
+// reuse is set, ErrClusterExists when it exists otherwise and an empty id when
+// it does not exist
+func (r *Provider) existingCluster(ctx context.Context, clusterName string, reuse bool, findCluster func(context.Context, string) (*clustersmgmtv1.Cluster, error)) (string, error) {
+	cluster, err := findCluster(ctx, clusterName)
+	if errors.Is(err, ErrClusterNotFound) {
+		return "", nil
+	}
 	if err != nil {
-		return clusterID, &clusterError{action: action, err: err}
+		return "", err
 	}
 
-	if !options.SkipHealthCheck {
-		kubeconfigFile, err := r.Client.KubeconfigFile(ctx, clusterID, os.TempDir())
-		if err != nil {
-			return clusterID, &clusterError{action: action, err: err}
-		}
+	if !reuse {
+		return "", fmt.Errorf("%w: %q (%s) in ocm %q", ErrClusterExists, clusterName, cluster.ID(), r.ocmEnvironment)
+	}
+
+	r.log.Info("Reusing existing cluster", clusterNameLoggerKey, clusterName, clusterIDLoggerKey, cluster.ID(),
+		"state", cluster.State(), ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return cluster.ID(), nil
+}
+
+// waitForCreatedCluster waits for the cluster to be installed and, unless
+// skipped, healthy
+func (r *Provider) waitForCreatedCluster(ctx context.Context, result *CreateClusterResult, options *CreateClusterOptions) (*CreateClusterResult, error) {
+	const action = "create"
 
-		client, err := openshiftclient.NewFromKubeconfig(kubeconfigFile, r.log)
+	clusterID := result.ClusterID
+
+	err := r.waitForClusterToBeInstalled(ctx, clusterID, options.ClusterName, options.ArtifactDir, options.InstallTimeout)
+	if err != nil {
+		return result, &clusterError{action: action, err: err}
+	}
+
+	if !options.SkipHealthCheck {
+		client, err := r.clusterClient(ctx, clusterID, options.ClientRetryAttempts)
 		if err != nil {
-			return clusterID, &clusterError{action: action, err: err}
+			return result, &clusterError{action: action, err: err}
 		}
 
 		err = r.waitForClusterToBeHealthy(
@@ -201,22 +462,24 @@ func (r *Provider) CreateCluster(ctx context.Context, options *CreateClusterOpti
 			options.HealthCheckTimeout,
 		)
 		if err != nil {
-			return clusterID, &clusterError{action: action, err: err}
+			return result, &clusterError{action: action, err: err}
 		}
 	}
 
-	return clusterID, nil
+	return result, nil
 }
 
 // DeleteCluster deletes a rosa cluster using the provided inputs
 func (r *Provider) DeleteCluster(ctx context.Context, options *DeleteClusterOptions) error {
 	const action = "delete"
 
+	r = r.withOperationID()
+
 	options.setDefaultDeleteClusterOptions()
 
 	cluster, err := r.findCluster(ctx, options.ClusterName)
 	if err != nil {
-		return &clusterError{action: action, err: fmt.Errorf("failed to locate cluster in ocm environment: %s: %s", r.ocmEnvironment, err)}
+		return &clusterError{action: action, err: fmt.Errorf("failed to locate cluster in ocm environment: %s: %w", r.ocmEnvironment, err)}
 	}
 
 	if options.HostedCP || options.PrivateLink {
@@ -233,45 +496,93 @@ func (r *Provider) DeleteCluster(ctx context.Context, options *DeleteClusterOpti
 		return &clusterError{action: action, err: err}
 	}
 
-	if options.STS || options.PrivateLink {
+	if err = r.deleteClusterResources(ctx, cluster, options, r.operatorRolesInUse); err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	r.createdResources.remove(options.ClusterName)
+
+	return nil
+}
+
+// deleteClusterResources deletes the iam and network resources left behind by
+// the deleted cluster, keeping the ones the options skip
+func (r *Provider) deleteClusterResources(ctx context.Context, cluster *clustersmgmtv1.Cluster, options *DeleteClusterOptions,
+	operatorRolesInUse func(context.Context, string) (bool, error),
+) error {
+	var err error
+
+	externalOIDC := options.ExternalOIDC
+	resources, tracked := r.createdResources.get(options.ClusterName)
+	if tracked && resources.externalOIDC {
+		externalOIDC = true
+	}
+
+	if externalOIDC {
+		r.log.Info("Skipping externally managed oidc config and operator roles cleanup", clusterNameLoggerKey, options.ClusterName,
+			oidcConfigIDLoggerKey, options.oidcConfigID)
+	}
+
+	if (options.STS || options.PrivateLink) && !externalOIDC {
 		operatorRolePrefix := cluster.AWS().STS().OperatorRolePrefix()
-		err = r.deleteOperatorRoles(ctx, cluster.ID(), operatorRolePrefix, options.oidcConfigID)
+		err = r.deleteUnusedOperatorRoles(ctx, cluster.ID(), operatorRolePrefix, options.oidcConfigID, operatorRolesInUse)
 		if err != nil {
-			return &clusterError{action: action, err: err}
+			return err
 		}
 
-		err = r.deleteOIDCConfigProvider(ctx, cluster.ID(), options.oidcConfigID)
-		if err != nil {
-			return &clusterError{action: action, err: err}
+		if options.SkipOIDCDeletion {
+			r.log.Info("Skipping oidc config provider deletion", clusterNameLoggerKey, options.ClusterName, oidcConfigIDLoggerKey, options.oidcConfigID)
+		} else {
+			err = r.deleteOIDCConfigProvider(ctx, cluster.ID(), options.oidcConfigID)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	if options.HostedCP || options.PrivateLink {
-		if options.DeleteOidcConfigID {
+		if options.DeleteOidcConfigID && !externalOIDC && !options.SkipOIDCDeletion {
 			err := r.DeleteOIDCConfig(ctx, options.oidcConfigID)
 			if err != nil {
-				return &clusterError{action: action, err: err}
+				return err
 			}
 		}
 
 		if options.DeleteHostedVPC {
+			// the vpc state lives in the clusters directory under the working directory it was created from
+			workingDir := vpcWorkingDir(options.WorkingDir, cluster.Name())
+			if resources.network != nil {
+				workingDir = resources.network.WorkingDir
+				r.log.Info("Deleting the vpc created with the cluster", clusterNameLoggerKey, options.ClusterName, "vpc_id", resources.network.VPCID)
+			}
+
 			err = r.deleteVPC(
 				ctx,
 				cluster.Name(),
 				r.awsCredentials.Region,
-				options.WorkingDir,
+				workingDir,
 			)
 			if err != nil {
-				return &clusterError{action: action, err: err}
+				return err
 			}
 		}
 	}
 
 	if options.STS {
-		if !strings.Contains(cluster.AWS().STS().RoleARN(), defaultAccountRolesPrefix) {
-			err = r.DeleteAccountRoles(ctx, options.ClusterName)
+		accountRolesPrefix := accountRolesPrefixFromRoleARN(cluster.AWS().STS().RoleARN())
+		if accountRolesPrefix == "" {
+			accountRolesPrefix = options.ClusterName
+		}
+
+		switch {
+		case options.SkipAccountRoleDeletion:
+			r.log.Info("Skipping account roles deletion", prefixLoggerKey, accountRolesPrefix, clusterNameLoggerKey, options.ClusterName)
+		case isDefaultAccountRolesPrefix(accountRolesPrefix):
+			r.log.Info("Skipping shared account roles deletion", prefixLoggerKey, accountRolesPrefix, clusterNameLoggerKey, options.ClusterName)
+		default:
+			err = r.DeleteAccountRoles(ctx, accountRolesPrefix)
 			if err != nil {
-				return &clusterError{action: action, err: err}
+				return err
 			}
 		}
 	}
@@ -284,7 +595,7 @@ func (r *Provider) validateCreateClusterOptions(options *CreateClusterOptions) (
 	var errs []error
 
 	if options.ClusterName == "" {
-		errs = append(errs, errors.New("cluster name is required"))
+		errs = append(errs, ErrClusterNameRequired)
 	}
 
 	if options.ChannelGroup == "" {
@@ -296,17 +607,36 @@ func (r *Provider) validateCreateClusterOptions(options *CreateClusterOptions) (
 	}
 
 	if options.MachineCidr == "" {
-		options.MachineCidr = "10.0.0.0/16"
+		options.MachineCidr = defaultMachineCIDR
+	}
+
+	if _, _, err := net.ParseCIDR(options.MachineCidr); err != nil {
+		errs = append(errs, fmt.Errorf("machine cidr %q is invalid: %v", options.MachineCidr, err))
 	}
 
 	if options.Version == "" {
-		errs = append(errs, errors.New("cluster version is required"))
+		errs = append(errs, ErrClusterVersionRequired)
 	}
 
 	if options.Replicas == 0 {
 		options.Replicas = 2
 	}
 
+	if options.WorkerDiskSize != "" {
+		size, err := parseWorkerDiskSize(options.WorkerDiskSize, options.HostedCP)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			options.WorkerDiskSize = fmt.Sprintf("%dGiB", size)
+		}
+	}
+
+	if options.BaseDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(options.BaseDomain) {
+			errs = append(errs, fmt.Errorf("base domain %q is invalid: %s", options.BaseDomain, msg))
+		}
+	}
+
 	if options.HostedCP {
 		if options.OidcConfigID == "" {
 			errs = append(errs, errors.New("oidc config id is required for hosted control plane clusters"))
@@ -317,6 +647,41 @@ func (r *Provider) validateCreateClusterOptions(options *CreateClusterOptions) (
 		}
 	}
 
+	errs = append(errs, validateVisibility(options)...)
+
+	switch options.EC2MetadataHTTPTokens {
+	case "", EC2MetadataHTTPTokensOptional, EC2MetadataHTTPTokensRequired:
+	default:
+		errs = append(errs, fmt.Errorf("ec2 metadata http tokens %q is invalid, must be %q or %q",
+			options.EC2MetadataHTTPTokens, EC2MetadataHTTPTokensOptional, EC2MetadataHTTPTokensRequired))
+	}
+
+	if options.VPCAvailabilityZones < 0 || options.VPCAvailabilityZones > defaultMultiAZAvailableZones {
+		errs = append(errs, fmt.Errorf("vpc availability zones must be between 1 and %d, got %d", defaultMultiAZAvailableZones, options.VPCAvailabilityZones))
+	}
+
+	if options.MultiAZ && !options.HostedCP && options.VPCAvailabilityZones != 0 && options.VPCAvailabilityZones != defaultMultiAZAvailableZones {
+		errs = append(errs, fmt.Errorf("multi az classic clusters require %d vpc availability zones, got %d", defaultMultiAZAvailableZones, options.VPCAvailabilityZones))
+	}
+
+	if options.VPCPrivateSubnetsOnly && !options.PrivateLink && options.APIVisibility != VisibilityInternal {
+		errs = append(errs, errors.New("vpc private subnets only requires a private link or private api cluster"))
+	}
+
+	if options.ExternalOIDC {
+		if !options.HostedCP && !options.STS {
+			errs = append(errs, errors.New("external oidc requires a hosted control plane or sts cluster"))
+		}
+
+		if options.OidcConfigID == "" {
+			errs = append(errs, errors.New("oidc config id is required when using an external oidc config"))
+		}
+
+		if options.OperatorRolesPrefix == "" {
+			errs = append(errs, errors.New("operator roles prefix is required when using an external oidc config"))
+		}
+	}
+
 	if options.HostedCP || options.STS {
 		if options.accountRoles.controlPlaneRoleARN == "" {
 			errs = append(errs, errors.New("iam role arn for control plane is required"))
@@ -339,12 +704,75 @@ func (r *Provider) validateCreateClusterOptions(options *CreateClusterOptions) (
 		for _, err := range errs {
 			r.log.Error(err, "create cluster option undefined")
 		}
-		return options, errors.New("one or more create cluster options are missing")
+		return options, fmt.Errorf("one or more create cluster options are missing: %w", errors.Join(errs...))
 	}
 
 	return options, nil
 }
 
+// parseWorkerDiskSize returns the worker disk size in GiB, validating it is
+// within the range rosa allows
+func parseWorkerDiskSize(workerDiskSize string, hostedCP bool) (int, error) {
+	match := workerDiskSizePattern.FindStringSubmatch(strings.TrimSpace(workerDiskSize))
+	if match == nil {
+		return 0, fmt.Errorf("worker disk size %q is invalid, must be a number of GiB or TiB, e.g. 300GiB", workerDiskSize)
+	}
+
+	size, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("worker disk size %q is invalid: %v", workerDiskSize, err)
+	}
+	if match[2] == "TiB" {
+		size *= 1024
+	}
+
+	minSize := minWorkerDiskSize
+	if hostedCP {
+		minSize = minHostedCPWorkerDiskSize
+	}
+
+	if size < minSize || size > maxWorkerDiskSize {
+		return 0, fmt.Errorf("worker disk size %q must be between %dGiB and %dGiB", workerDiskSize, minSize, maxWorkerDiskSize)
+	}
+
+	return size, nil
+}
+
+// validateVisibility verifies the api and ingress visibility options are compatible
+func validateVisibility(options *CreateClusterOptions) []error {
+	var errs []error
+
+	for name, visibility := range map[string]Visibility{"api": options.APIVisibility, "ingress": options.IngressVisibility} {
+		if visibility != "" && visibility != VisibilityExternal && visibility != VisibilityInternal {
+			errs = append(errs, fmt.Errorf("%s visibility %q is invalid, must be %q or %q", name, visibility, VisibilityExternal, VisibilityInternal))
+		}
+	}
+
+	if options.PrivateLink && options.APIVisibility == VisibilityExternal {
+		errs = append(errs, errors.New("private link clusters require an internal api visibility"))
+	}
+
+	if options.APIVisibility == VisibilityInternal && !options.PrivateLink && options.SubnetIDs == "" {
+		errs = append(errs, errors.New("subnet ids is required for clusters with an internal api visibility"))
+	}
+
+	if options.Private {
+		if options.PrivateLink {
+			errs = append(errs, errors.New("private and private link are mutually exclusive"))
+		}
+
+		if options.APIVisibility == VisibilityExternal || options.IngressVisibility == VisibilityExternal {
+			errs = append(errs, errors.New("private clusters require an internal api and ingress visibility"))
+		}
+
+		if options.SubnetIDs == "" {
+			errs = append(errs, errors.New("subnet ids is required for private clusters"))
+		}
+	}
+
+	return errs
+}
+
 // createCluster handles sending the request to create the cluster
 func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOptions) (string, error) {
 	options, err := r.validateCreateClusterOptions(options)
@@ -352,6 +780,66 @@ func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOpti
 		return "", fmt.Errorf("cluster options validation failed: %v", err)
 	}
 
+	commandArgs := r.createClusterCommandArgs(options)
+
+	r.log.Info("Initiating cluster creation", clusterNameLoggerKey, options.ClusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	_, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+	if err != nil {
+		return "", quotaError(fmt.Sprint(stderr), fmt.Errorf("error: %v, stderr: %v", err, stderr))
+	}
+
+	cluster, err := r.findCluster(ctx, options.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	clusterID := cluster.ID()
+
+	r.log.Info("Cluster creation initiated!", clusterNameLoggerKey, options.ClusterName,
+		clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return clusterID, err
+}
+
+// createClusterDryRun validates the options and returns the rosa create cluster
+// command, using placeholders for the resources created before the cluster
+func (r *Provider) createClusterDryRun(options *CreateClusterOptions) ([]string, error) {
+	if options.HostedCP || options.STS {
+		options.accountRoles = AccountRoles{
+			controlPlaneRoleARN: "<control-plane-role-arn>",
+			installerRoleARN:    "<installer-role-arn>",
+			supportRoleARN:      "<support-role-arn>",
+			workerRoleARN:       "<worker-role-arn>",
+			hcpInstallerRoleARN: "<hcp-installer-role-arn>",
+			hcpSupportRoleARN:   "<hcp-support-role-arn>",
+			hcpWorkerRoleARN:    "<hcp-worker-role-arn>",
+		}
+
+		if options.OidcConfigID == "" && !options.ExternalOIDC {
+			options.OidcConfigID = "<oidc-config-id>"
+		}
+	}
+
+	if (options.HostedCP || options.PrivateLink) && options.SubnetIDs == "" {
+		options.SubnetIDs = "<subnet-ids>"
+	}
+
+	options, err := r.validateCreateClusterOptions(options)
+	if err != nil {
+		return nil, fmt.Errorf("cluster options validation failed: %w", err)
+	}
+
+	command := append([]string{"rosa"}, r.createClusterCommandArgs(options)...)
+
+	r.log.Info("Dry run, skipping cluster creation", clusterNameLoggerKey, options.ClusterName,
+		"command", strings.Join(command, " "), ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return command, nil
+}
+
+// createClusterCommandArgs builds the rosa create cluster command arguments from the validated options
+func (r *Provider) createClusterCommandArgs(options *CreateClusterOptions) []string {
 	commandArgs := []string{
 		"create", "cluster",
 		"--output", "json",
@@ -417,13 +905,25 @@ func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOpti
 
 	if options.PrivateLink {
 		commandArgs = append(commandArgs, "--private-link")
-		commandArgs = append(commandArgs, "--machine-cidr=10.0.0.0/16")
+	}
+
+	// private link already implies a private api
+	if options.Private || (options.APIVisibility == VisibilityInternal && !options.PrivateLink) {
+		commandArgs = append(commandArgs, "--private")
+	}
+
+	if options.IngressVisibility == VisibilityInternal {
+		commandArgs = append(commandArgs, "--default-ingress-private")
 	}
 
 	if options.FIPS {
 		commandArgs = append(commandArgs, "--fips")
 	}
 
+	if options.EC2MetadataHTTPTokens != "" {
+		commandArgs = append(commandArgs, "--ec2-metadata-http-tokens", string(options.EC2MetadataHTTPTokens))
+	}
+
 	if options.NetworkType != "" && options.NetworkType != "OVNKubernetes" {
 		commandArgs = append(commandArgs, "--network-type", options.NetworkType)
 	}
@@ -444,6 +944,14 @@ func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOpti
 		commandArgs = append(commandArgs, "--etcd-encryption")
 	}
 
+	if options.DisableWorkloadMonitoring {
+		commandArgs = append(commandArgs, "--disable-workload-monitoring")
+	}
+
+	if options.WorkerDiskSize != "" {
+		commandArgs = append(commandArgs, "--worker-disk-size", options.WorkerDiskSize)
+	}
+
 	if options.MinReplicas > 0 {
 		commandArgs = append(commandArgs, "--min-replicas", fmt.Sprint(options.MinReplicas))
 	}
@@ -479,39 +987,52 @@ func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOpti
 		commandArgs = append(commandArgs, "--expiration-time", time.Now().Add(options.ExpirationDuration).UTC().Format(time.RFC3339))
 	}
 
-	r.log.Info("Initiating cluster creation", clusterNameLoggerKey, options.ClusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+	if options.BaseDomain != "" {
+		commandArgs = append(commandArgs, "--base-domain", options.BaseDomain)
+	}
 
-	_, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
-	if err != nil {
-		return "", fmt.Errorf("error: %v, stderr: %v", err, stderr)
+	if options.OperatorRolesPrefix != "" {
+		commandArgs = append(commandArgs, "--operator-roles-prefix", options.OperatorRolesPrefix)
 	}
 
-	cluster, err := r.findCluster(ctx, options.ClusterName)
-	if err != nil {
-		return "", err
+	if len(options.AdditionalComputeSecurityGroupIDs) > 0 {
+		commandArgs = append(commandArgs, "--additional-compute-security-group-ids", strings.Join(options.AdditionalComputeSecurityGroupIDs, ","))
 	}
 
-	clusterID := cluster.ID()
+	if len(options.AdditionalInfraSecurityGroupIDs) > 0 {
+		commandArgs = append(commandArgs, "--additional-infra-security-group-ids", strings.Join(options.AdditionalInfraSecurityGroupIDs, ","))
+	}
 
-	r.log.Info("Cluster creation initiated!", clusterNameLoggerKey, options.ClusterName,
-		clusterIDLoggerKey, clusterID, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+	if len(options.AdditionalControlPlaneSecurityGroupIDs) > 0 {
+		commandArgs = append(commandArgs, "--additional-control-plane-security-group-ids", strings.Join(options.AdditionalControlPlaneSecurityGroupIDs, ","))
+	}
 
-	return clusterID, err
+	return commandArgs
 }
 
 // findCluster gets the cluster the body
 func (r *Provider) findCluster(ctx context.Context, clusterName string) (*clustersmgmtv1.Cluster, error) {
 	query := fmt.Sprintf("product.id = 'rosa' AND (name = '%[1]s' OR id = '%[1]s')", clusterName)
-	response, err := r.ClustersMgmt().V1().Clusters().List().
-		Search(query).
-		Page(1).
-		Size(1).
-		SendContext(ctx)
 
-	if response.Total() == 1 {
-		return response.Items().Slice()[0], nil
+	var response *clustersmgmtv1.ClustersListResponse
+	err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = r.ClustersMgmt().V1().Clusters().List().
+			Search(query).
+			Page(1).
+			Size(1).
+			SendContext(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cluster %q in ocm %q: %v", clusterName, r.ocmEnvironment, err)
+	}
+
+	if response.Total() != 1 {
+		return nil, fmt.Errorf("%w: %q in ocm %q", ErrClusterNotFound, clusterName, r.ocmEnvironment)
 	}
-	return nil, fmt.Errorf("cluster %q not found in ocm %q: %v", clusterName, r.ocmEnvironment, err)
+	return response.Items().Slice()[0], nil
 }
 
 // deleteCluster handles sending the request to delete the cluster
@@ -538,9 +1059,43 @@ func (r *Provider) deleteCluster(ctx context.Context, clusterID string) error {
 	return err
 }
 
+// clusterStatus represents the status of the rosa describe cluster output
+type clusterStatus struct {
+	State                 string `json:"state"`
+	Description           string `json:"description"`
+	ProvisionErrorCode    string `json:"provision_error_code"`
+	ProvisionErrorMessage string `json:"provision_error_message"`
+}
+
+// clusterDescription represents the subset of the rosa describe cluster output used
+type clusterDescription struct {
+	Status *clusterStatus `json:"status"`
+}
+
+// parseClusterStatus returns the cluster status from the rosa describe cluster json output
+func parseClusterStatus(output string) (*clusterStatus, error) {
+	var description clusterDescription
+
+	if err := json.Unmarshal([]byte(output), &description); err != nil {
+		return nil, fmt.Errorf("failed to parse describe cluster output: %v", err)
+	}
+
+	if description.Status == nil {
+		return nil, errors.New("describe cluster output is missing the status field")
+	}
+
+	if description.Status.State == "" {
+		return nil, errors.New("describe cluster output is missing the status.state field")
+	}
+
+	return description.Status, nil
+}
+
 // waitForClusterToBeInstalled waits for the cluster to be in a ready state
 func (r *Provider) waitForClusterToBeInstalled(ctx context.Context, clusterID, clusterName, reportDir string, timeout time.Duration) error {
-	getClusterState := func() (string, error) {
+	var describeOutput string
+
+	getClusterStatus := func() (*clusterStatus, error) {
 		commandArgs := []string{
 			"describe", "cluster",
 			"--cluster", clusterID,
@@ -549,41 +1104,54 @@ func (r *Provider) waitForClusterToBeInstalled(ctx context.Context, clusterID, c
 
 		stdout, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
 		if err != nil {
-			return "", fmt.Errorf("error: %v, stderr: %v", err, stderr)
+			return nil, fmt.Errorf("error: %v, stderr: %v", err, stderr)
 		}
 
-		output, err := cmd.ConvertOutputToMap(stdout)
-		if err != nil {
-			return "", fmt.Errorf("failed to convert output to map: %v", err)
-		}
+		describeOutput = fmt.Sprint(stdout)
 
-		clusterState := fmt.Sprint(output["status"].(map[string]any)["state"])
-
-		return clusterState, err
+		return parseClusterStatus(describeOutput)
 	}
 
 	r.log.Info("Waiting for cluster to be installed", clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName, timeoutLoggerKey, timeout.Round(time.Second).String(), ocmEnvironmentLoggerKey, r.ocmEnvironment)
 
-	err := wait.For(func(ctx context.Context) (bool, error) {
-		clusterState, err := getClusterState()
+	err := r.waitUntil(ctx, 30*time.Second, timeout, func(ctx context.Context) (bool, error) {
+		status, err := getClusterStatus()
 		if err != nil {
 			return false, err
 		}
 
-		if clusterState != "ready" {
-			r.log.Info("Cluster not in ready state", clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName, clusterStateLoggerKey, clusterState, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+		if status.State != "ready" {
+			keysAndValues := []any{
+				clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName, clusterStateLoggerKey, status.State,
+				clusterStatusDescriptionLoggerKey, status.Description, ocmEnvironmentLoggerKey, r.ocmEnvironment,
+			}
+			if status.ProvisionErrorMessage != "" {
+				keysAndValues = append(keysAndValues, "provision_error_code", status.ProvisionErrorCode,
+					"provision_error_message", status.ProvisionErrorMessage)
+			}
+			r.log.Info("Cluster not in ready state", keysAndValues...)
 			return false, nil
 		}
 
 		r.log.Info("Cluster is ready!", clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
 		return true, nil
-	}, wait.WithTimeout(timeout), wait.WithInterval(30*time.Second))
+	})
 	if err != nil {
+		// the wait context may be expired, collect with a fresh one
+		collectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Minute)
+		defer cancel()
+		r.collectInstallFailureArtifacts(collectCtx, clusterID, clusterName, reportDir, describeOutput)
 		return fmt.Errorf("cluster %q failed to enter ready state in the alloted time %q: %w", clusterID, timeout, err)
 	}
 	return nil
 }
 
+// clusterClient constructs the cluster client, retrying as the admin credentials
+// can take a moment to propagate after the cluster becomes ready
+func (r *Provider) clusterClient(ctx context.Context, clusterID string, attempts int) (*openshiftclient.Client, error) {
+	return r.Client.ClusterClient(ctx, clusterID, r.PollStrategy.WithDefaultInterval(30*time.Second), attempts, r.log)
+}
+
 // waitForClusterToBeHealthy waits for the cluster health check job to succeed
 func (r *Provider) waitForClusterToBeHealthy(ctx context.Context, client *openshiftclient.Client, clusterName, reportDir string, hostedCP bool, timeout time.Duration) error {
 	if hostedCP {
@@ -597,24 +1165,106 @@ func (r *Provider) waitForClusterToBeHealthy(ctx context.Context, client *opensh
 	return client.OSDClusterHealthy(ctx, reportDir, timeout)
 }
 
+// WaitForClusterHealthy waits for an installed cluster to pass the health check,
+// used when the health check was skipped at cluster creation
+func (r *Provider) WaitForClusterHealthy(ctx context.Context, clusterID, reportDir string, timeout time.Duration) error {
+	const action = "health check"
+
+	cluster, err := r.findCluster(ctx, clusterID)
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	client, err := r.clusterClient(ctx, cluster.ID(), defaultClientRetryAttempts)
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	if err = r.waitForClusterToBeHealthy(ctx, client, cluster.ID(), reportDir, cluster.Hypershift().Enabled(), timeout); err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// ClusterHandle represents an existing cluster adopted by the provider
+type ClusterHandle struct {
+	ID   string
+	Name string
+	// Product and BillingModel are the ocm product (e.g. rosa, osd) and billing
+	// model (e.g. standard, marketplace-aws) the cluster was created with
+	Product      string
+	BillingModel string
+	Client       *openshiftclient.Client
+}
+
+// AdoptCluster returns a handle to an existing ready cluster found by name or
+// id, used to test clusters provisioned outside of the providers session
+func (r *Provider) AdoptCluster(ctx context.Context, clusterName string) (*ClusterHandle, error) {
+	return r.adoptCluster(ctx, clusterName, r.findCluster, r.clusterClient)
+}
+
+// adoptCluster looks up the ready cluster and constructs its client
+func (r *Provider) adoptCluster(ctx context.Context, clusterName string,
+	findCluster func(context.Context, string) (*clustersmgmtv1.Cluster, error),
+	clusterClient func(context.Context, string, int) (*openshiftclient.Client, error),
+) (*ClusterHandle, error) {
+	const action = "adopt"
+
+	cluster, err := findCluster(ctx, clusterName)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	if cluster.State() != clustersmgmtv1.ClusterStateReady {
+		return nil, &clusterError{action: action, err: fmt.Errorf("cluster %q is %s, expected %s", clusterName, cluster.State(), clustersmgmtv1.ClusterStateReady)}
+	}
+
+	client, err := clusterClient(ctx, cluster.ID(), defaultClientRetryAttempts)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	product, billingModel := ocm.ClusterProductOf(cluster)
+
+	r.log.Info("Cluster adopted!", clusterIDLoggerKey, cluster.ID(), clusterNameLoggerKey, cluster.Name(), ocmEnvironmentLoggerKey, r.ocmEnvironment,
+		"product", product, "billing_model", billingModel)
+
+	return &ClusterHandle{ID: cluster.ID(), Name: cluster.Name(), Product: product, BillingModel: billingModel, Client: client}, nil
+}
+
 // waitForClusterToBeDeleted waits for the cluster to be deleted
 func (r *Provider) waitForClusterToBeDeleted(ctx context.Context, clusterName, reportDir string, timeout time.Duration) error {
-	defer func() {
-		// TODO: Fix this, logs are unavailable once cluster is deleted
-		err := r.clusterLog(ctx, "uninstall", clusterName, reportDir)
-		r.log.Error(err, "failed to get cluster uninstall log", clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
-	}()
+	return r.waitForClusterDeletion(ctx, clusterName, reportDir, timeout, r.findCluster)
+}
 
-	err := wait.For(func(ctx context.Context) (bool, error) {
-		cluster, err := r.findCluster(ctx, clusterName)
+// waitForClusterDeletion waits for the cluster to be deleted, collecting the
+// uninstall log while the cluster uninstalls as it is unavailable once deleted
+func (r *Provider) waitForClusterDeletion(ctx context.Context, clusterName, reportDir string, timeout time.Duration, findCluster func(context.Context, string) (*clustersmgmtv1.Cluster, error)) error {
+	var (
+		logCollected bool
+		logErr       error
+	)
+
+	err := r.waitUntil(ctx, 30*time.Second, timeout, func(ctx context.Context) (bool, error) {
+		cluster, err := findCluster(ctx, clusterName)
 		if err == nil && cluster != nil {
 			r.log.Info("Cluster is uninstalling...", clusterNameLoggerKey, clusterName, clusterStateLoggerKey, cluster.State(), ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+			if logErr = r.clusterLog(ctx, "uninstall", clusterName, reportDir); logErr == nil {
+				logCollected = true
+			}
 			return false, nil
 		}
 
 		r.log.Info("Cluster no longer exists!", clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
 		return true, nil
-	}, wait.WithTimeout(timeout), wait.WithInterval(30*time.Second))
+	})
+
+	if !logCollected && logErr != nil {
+		r.log.Error(logErr, "failed to get cluster uninstall log", clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+	}
+
 	if err != nil {
 		return fmt.Errorf("cluster %q failed to finish uninstalling in the alloted time", clusterName)
 	}
@@ -636,9 +1286,17 @@ func (o *CreateClusterOptions) setDefaultCreateClusterOptions() {
 		o.ArtifactDir = os.TempDir()
 	}
 
+	if o.ClientRetryAttempts == 0 {
+		o.ClientRetryAttempts = defaultClientRetryAttempts
+	}
+
 	if o.WorkingDir == "" {
 		o.WorkingDir = os.TempDir()
 	}
+
+	if o.MachineCidr == "" {
+		o.MachineCidr = defaultMachineCIDR
+	}
 }
 
 func (o *CreateClusterOptions) setInstallTimeout(duration time.Duration) {
@@ -0,0 +1,56 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/openshift/osde2e-common/internal/cmd"
+)
+
+// dnsDomainError represents the custom error
+type dnsDomainError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when dnsDomainError is invoked
+func (d *dnsDomainError) Error() string {
+	return fmt.Sprintf("dns domain %s failed: %v", d.action, d.err)
+}
+
+// Unwrap returns the error wrapped by dnsDomainError
+func (d *dnsDomainError) Unwrap() error {
+	return d.err
+}
+
+// dnsDomainCheck verifies the base domain provided is registered with the organization
+func (r *Provider) dnsDomainCheck(ctx context.Context, baseDomain string) error {
+	const action = "check"
+
+	commandArgs := []string{
+		"list", "dns-domain",
+		"--output", "json",
+	}
+
+	r.log.Info("Performing ROSA dns domain check", baseDomainLoggerKey, baseDomain, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	stdout, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+	if err != nil {
+		return &dnsDomainError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+	}
+
+	dnsDomains, err := cmd.ConvertOutputToListOfMaps(stdout)
+	if err != nil {
+		return &dnsDomainError{action: action, err: fmt.Errorf("failed to convert output to list of maps: %v", err)}
+	}
+
+	for _, dnsDomain := range dnsDomains {
+		if fmt.Sprint(dnsDomain["id"]) == baseDomain {
+			r.log.Info("ROSA dns domain check passed", baseDomainLoggerKey, baseDomain, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+			return nil
+		}
+	}
+
+	return &dnsDomainError{action: action, err: fmt.Errorf("base domain %q is not registered with the organization", baseDomain)}
+}
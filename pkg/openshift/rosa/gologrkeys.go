@@ -2,17 +2,21 @@ package rosa
 
 // Constants defining commonly used go-logr keys
 const (
-	awsRegionLoggerKey           = "aws_region"
-	clusterChannelGroupLoggerKey = "channel_group"
-	clusterLogTypeLoggerKey      = "cluster_log"
-	clusterNameLoggerKey         = "cluster_name"
-	clusterIDLoggerKey           = "cluster_id"
-	clusterStateLoggerKey        = "cluster_state"
-	ocmEnvironmentLoggerKey      = "ocm_environment"
-	oidcConfigIDLoggerKey        = "oidc_config_id"
-	prefixLoggerKey              = "prefix"
-	rosaCommandLoggerKey         = "rosa_command"
-	terraformWorkingDirLoggerKey = "terraform_working_dir"
-	timeoutLoggerKey             = "timeout"
-	versionLoggerKey             = "version"
+	awsRegionLoggerKey                = "aws_region"
+	baseDomainLoggerKey               = "base_domain"
+	clusterChannelGroupLoggerKey      = "channel_group"
+	clusterLogTypeLoggerKey           = "cluster_log"
+	clusterNameLoggerKey              = "cluster_name"
+	clusterIDLoggerKey                = "cluster_id"
+	clusterStateLoggerKey             = "cluster_state"
+	clusterStatusDescriptionLoggerKey = "cluster_status_description"
+	machinePoolIDLoggerKey            = "machine_pool_id"
+	ocmEnvironmentLoggerKey           = "ocm_environment"
+	oidcConfigIDLoggerKey             = "oidc_config_id"
+	operationIDLoggerKey              = "operation_id"
+	prefixLoggerKey                   = "prefix"
+	rosaCommandLoggerKey              = "rosa_command"
+	terraformWorkingDirLoggerKey      = "terraform_working_dir"
+	timeoutLoggerKey                  = "timeout"
+	versionLoggerKey                  = "version"
 )
@@ -35,3 +35,40 @@ func (r *Provider) clusterLog(ctx context.Context, logType, clusterName, reportD
 
 	return nil
 }
+
+// writeClusterDescribe writes the rosa describe cluster output to the report directory
+func writeClusterDescribe(reportDir, clusterName, output string) error {
+	if err := os.WriteFile(fmt.Sprintf("%s/%s-describe.json", reportDir, clusterName), []byte(output), os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("failed to write cluster describe to file: %v", err)
+	}
+	return nil
+}
+
+// collectInstallFailureArtifacts writes the describe cluster output and the install
+// log to the report directory when the cluster fails to install. The last describe
+// output seen while waiting is used when the cluster can no longer be described
+func (r *Provider) collectInstallFailureArtifacts(ctx context.Context, clusterID, clusterName, reportDir, lastDescribeOutput string) {
+	if reportDir == "" {
+		return
+	}
+
+	describeOutput := lastDescribeOutput
+	commandArgs := []string{
+		"describe", "cluster",
+		"--cluster", clusterID,
+		"--output", "json",
+	}
+	if stdout, _, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...)); err == nil {
+		describeOutput = fmt.Sprint(stdout)
+	}
+
+	if describeOutput != "" {
+		if err := writeClusterDescribe(reportDir, clusterName, describeOutput); err != nil {
+			r.log.Error(err, "failed to collect cluster describe", clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName)
+		}
+	}
+
+	if err := r.clusterLog(ctx, "install", clusterName, reportDir); err != nil {
+		r.log.Error(err, "failed to collect cluster install log", clusterIDLoggerKey, clusterID, clusterNameLoggerKey, clusterName)
+	}
+}
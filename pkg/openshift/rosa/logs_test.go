@@ -0,0 +1,124 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+var _ = Describe("install failure artifacts", func() {
+	const describeOutput = `{"id": "123", "name": "test", "status": {"state": "installing", "description": "Installing cluster"}}`
+
+	var (
+		provider  *Provider
+		reportDir string
+	)
+
+	BeforeEach(func() {
+		reportDir = GinkgoT().TempDir()
+
+		// fake rosa cli that reports the cluster as installing and prints an install log
+		binDir := GinkgoT().TempDir()
+		rosaBinary := filepath.Join(binDir, "rosa")
+		script := "#!/bin/sh\nif [ \"$1\" = \"describe\" ]; then\n  echo '" + describeOutput + "'\nelse\n  echo 'install log'\nfi\n"
+		Expect(os.WriteFile(rosaBinary, []byte(script), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+			PollStrategy:   poll.Strategy{Interval: 10 * time.Millisecond},
+		}
+	})
+
+	It("should write the describe output and install log when the install times out", func(ctx context.Context) {
+		err := provider.waitForClusterToBeInstalled(ctx, "123", "test", reportDir, 50*time.Millisecond)
+		Expect(err).Should(HaveOccurred())
+
+		describe, err := os.ReadFile(filepath.Join(reportDir, "test-describe.json"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(describe)).Should(MatchJSON(describeOutput))
+
+		installLog, err := os.ReadFile(filepath.Join(reportDir, "test-install.log"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(installLog)).Should(Equal("install log\n"))
+	})
+})
+
+var _ = Describe("uninstall log", func() {
+	var (
+		provider  *Provider
+		reportDir string
+		errors    []string
+	)
+
+	fakeRosa := func(script string) string {
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte(script), 0o755)).Should(Succeed())
+		return rosaBinary
+	}
+
+	// findCluster reports the cluster as uninstalling for the number of calls
+	findCluster := func(uninstalling int) func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+		calls := 0
+		return func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+			calls++
+			if calls > uninstalling {
+				return nil, fmt.Errorf("cluster not found")
+			}
+			return clustersmgmtv1.NewCluster().Name("test").State(clustersmgmtv1.ClusterStateUninstalling).Build()
+		}
+	}
+
+	BeforeEach(func() {
+		reportDir = GinkgoT().TempDir()
+		errors = nil
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log: funcr.New(func(prefix, args string) {
+				if strings.Contains(args, `"error"`) {
+					errors = append(errors, args)
+				}
+			}, funcr.Options{}),
+			PollStrategy: poll.Strategy{Interval: time.Millisecond},
+		}
+	})
+
+	It("should collect the uninstall log while the cluster uninstalls", func(ctx context.Context) {
+		provider.rosaBinary = fakeRosa("#!/bin/sh\necho 'uninstall log'\n")
+
+		Expect(provider.waitForClusterDeletion(ctx, "test", reportDir, time.Second, findCluster(2))).Should(Succeed())
+
+		uninstallLog, err := os.ReadFile(filepath.Join(reportDir, "test-uninstall.log"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(uninstallLog)).Should(Equal("uninstall log\n"))
+		Expect(errors).Should(BeEmpty())
+	})
+
+	It("should not log an error when the cluster is already deleted", func(ctx context.Context) {
+		provider.rosaBinary = fakeRosa("#!/bin/sh\nexit 1\n")
+
+		Expect(provider.waitForClusterDeletion(ctx, "test", reportDir, time.Second, findCluster(0))).Should(Succeed())
+		Expect(errors).Should(BeEmpty())
+	})
+
+	It("should log an error once when the uninstall log is never retrieved", func(ctx context.Context) {
+		provider.rosaBinary = fakeRosa("#!/bin/sh\nexit 1\n")
+
+		Expect(provider.waitForClusterDeletion(ctx, "test", reportDir, time.Second, findCluster(3))).Should(Succeed())
+		Expect(errors).Should(HaveLen(1))
+		Expect(errors[0]).Should(ContainSubstring("failed to get cluster uninstall log"))
+	})
+})
@@ -0,0 +1,149 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	openshiftclient "github.com/openshift/osde2e-common/pkg/clients/openshift"
+)
+
+const (
+	defaultMachinePoolID           = "worker"
+	defaultHostedCPMachinePoolID   = "workers"
+	defaultMultiAZAvailableZones   = 3
+	defaultMachinePoolScaleTimeout = 30 * time.Minute
+)
+
+// ScaleMachinePoolOptions represents optional data used when scaling machine pools
+type ScaleMachinePoolOptions struct {
+	Timeout time.Duration
+}
+
+// machinePoolError represents the custom error
+type machinePoolError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when machinePoolError is invoked
+func (m *machinePoolError) Error() string {
+	return fmt.Sprintf("%s machine pool failed: %v", m.action, m.err)
+}
+
+// Unwrap returns the error wrapped by machinePoolError
+func (m *machinePoolError) Unwrap() error {
+	return m.err
+}
+
+// ScaleDefaultMachinePool sets the replica count of the clusters default machine
+// pool and waits for the worker node count to be reached
+func (r *Provider) ScaleDefaultMachinePool(ctx context.Context, clusterName string, replicas int, args ...*ScaleMachinePoolOptions) error {
+	return r.editDefaultMachinePool(ctx, clusterName, replicas, replicas, false, args...)
+}
+
+// AutoscaleDefaultMachinePool enables autoscaling on the clusters default machine
+// pool and waits for the worker node count to be within min and max replicas
+func (r *Provider) AutoscaleDefaultMachinePool(ctx context.Context, clusterName string, minReplicas, maxReplicas int, args ...*ScaleMachinePoolOptions) error {
+	return r.editDefaultMachinePool(ctx, clusterName, minReplicas, maxReplicas, true, args...)
+}
+
+// editDefaultMachinePool edits the clusters default machine pool replicas and waits for the nodes to settle
+func (r *Provider) editDefaultMachinePool(ctx context.Context, clusterName string, minReplicas, maxReplicas int, autoscaling bool, args ...*ScaleMachinePoolOptions) error {
+	const action = "scale"
+
+	options := &ScaleMachinePoolOptions{}
+	if len(args) == 1 && args[0] != nil {
+		options = args[0]
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultMachinePoolScaleTimeout
+	}
+
+	cluster, err := r.findCluster(ctx, clusterName)
+	if err != nil {
+		return &machinePoolError{action: action, err: err}
+	}
+
+	if err = validateMachinePoolReplicas(cluster, minReplicas, maxReplicas); err != nil {
+		return &machinePoolError{action: action, err: err}
+	}
+
+	machinePoolID := defaultMachinePoolID
+	if cluster.Hypershift().Enabled() {
+		machinePoolID = defaultHostedCPMachinePoolID
+	}
+
+	commandArgs := []string{
+		"edit", "machinepool",
+		"--cluster", cluster.ID(),
+	}
+
+	if autoscaling {
+		commandArgs = append(commandArgs,
+			"--enable-autoscaling",
+			"--min-replicas", fmt.Sprint(minReplicas),
+			"--max-replicas", fmt.Sprint(maxReplicas),
+		)
+	} else {
+		commandArgs = append(commandArgs,
+			"--enable-autoscaling=false",
+			"--replicas", fmt.Sprint(minReplicas),
+		)
+	}
+
+	commandArgs = append(commandArgs, machinePoolID)
+
+	r.log.Info("Scaling default machine pool", clusterNameLoggerKey, clusterName, machinePoolIDLoggerKey, machinePoolID,
+		"min_replicas", minReplicas, "max_replicas", maxReplicas, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	_, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+	if err != nil {
+		return &machinePoolError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+	}
+
+	kubeconfigFile, err := r.Client.KubeconfigFile(ctx, cluster.ID(), os.TempDir())
+	if err != nil {
+		return &machinePoolError{action: action, err: err}
+	}
+
+	client, err := openshiftclient.NewFromKubeconfig(kubeconfigFile, r.log)
+	if err != nil {
+		return &machinePoolError{action: action, err: err}
+	}
+
+	if err = client.WaitForMachinePoolNodes(ctx, cluster.Name(), machinePoolID, minReplicas, maxReplicas, options.Timeout); err != nil {
+		return &machinePoolError{action: action, err: err}
+	}
+
+	r.log.Info("Default machine pool scaled!", clusterNameLoggerKey, clusterName, machinePoolIDLoggerKey, machinePoolID,
+		"min_replicas", minReplicas, "max_replicas", maxReplicas, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return nil
+}
+
+// validateMachinePoolReplicas verifies the replica counts are valid for the cluster
+func validateMachinePoolReplicas(cluster *clustersmgmtv1.Cluster, minReplicas, maxReplicas int) error {
+	if minReplicas < 0 || maxReplicas < minReplicas {
+		return fmt.Errorf("invalid replicas, min: %d, max: %d", minReplicas, maxReplicas)
+	}
+
+	if !cluster.MultiAZ() || cluster.Hypershift().Enabled() {
+		return nil
+	}
+
+	availabilityZones := len(cluster.Nodes().AvailabilityZones())
+	if availabilityZones == 0 {
+		availabilityZones = defaultMultiAZAvailableZones
+	}
+
+	if minReplicas%availabilityZones != 0 || maxReplicas%availabilityZones != 0 {
+		return fmt.Errorf("multi az clusters require replicas to be divisible by the number of availability zones (%d), min: %d, max: %d",
+			availabilityZones, minReplicas, maxReplicas)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("machine pool replicas", func() {
+	buildCluster := func(multiAZ, hostedCP bool) *clustersmgmtv1.Cluster {
+		cluster, err := clustersmgmtv1.NewCluster().
+			MultiAZ(multiAZ).
+			Hypershift(clustersmgmtv1.NewHypershift().Enabled(hostedCP)).
+			Nodes(clustersmgmtv1.NewClusterNodes().AvailabilityZones("us-east-1a", "us-east-1b", "us-east-1c")).
+			Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		return cluster
+	}
+
+	It("should allow any replica count for single az clusters", func() {
+		Expect(validateMachinePoolReplicas(buildCluster(false, false), 2, 2)).Should(Succeed())
+	})
+
+	It("should require replicas divisible by the availability zones for multi az clusters", func() {
+		Expect(validateMachinePoolReplicas(buildCluster(true, false), 6, 9)).Should(Succeed())
+		Expect(validateMachinePoolReplicas(buildCluster(true, false), 4, 4)).ShouldNot(Succeed())
+		Expect(validateMachinePoolReplicas(buildCluster(true, false), 3, 5)).ShouldNot(Succeed())
+	})
+
+	It("should not require divisible replicas for hosted control plane clusters", func() {
+		Expect(validateMachinePoolReplicas(buildCluster(true, true), 2, 2)).Should(Succeed())
+	})
+
+	It("should reject a max replicas lower than min replicas", func() {
+		Expect(validateMachinePoolReplicas(buildCluster(false, false), 4, 2)).ShouldNot(Succeed())
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os/exec"
 	"strings"
 
@@ -12,6 +13,37 @@ import (
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+// OIDCConfigOptions represents optional data used when creating oidc configs
+type OIDCConfigOptions struct {
+	// IssuerURL is the url of a customer managed oidc issuer (e.g. s3 behind
+	// cloudfront), the oidc config is registered rather than created when set
+	IssuerURL string
+	// SecretARN is the secrets manager arn holding the issuers private key
+	SecretARN string
+}
+
+// validate verifies the external issuer options are valid
+func (o *OIDCConfigOptions) validate() error {
+	if o.IssuerURL == "" {
+		return nil
+	}
+
+	issuerURL, err := url.Parse(o.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("issuer url %q is invalid: %v", o.IssuerURL, err)
+	}
+
+	if issuerURL.Scheme != "https" || issuerURL.Host == "" || issuerURL.RawQuery != "" || issuerURL.Fragment != "" {
+		return fmt.Errorf("issuer url %q must be an https url without a query or fragment", o.IssuerURL)
+	}
+
+	if !strings.HasPrefix(o.SecretARN, "arn:") {
+		return fmt.Errorf("secret arn %q is invalid, it is required when using an external issuer url", o.SecretARN)
+	}
+
+	return nil
+}
+
 // oidcConfigError represents the custom error
 type oidcConfigError struct {
 	action string
@@ -23,15 +55,30 @@ func (o *oidcConfigError) Error() string {
 	return fmt.Sprintf("%s oidc config failed: %v", o.action, o.err)
 }
 
-// createOIDCConfig creates an oidc config if one does not already exist
-func (r *Provider) CreateOIDCConfig(ctx context.Context, prefix, installerRoleArn string) (string, error) {
+// Unwrap returns the error wrapped by oidcConfigError
+func (o *oidcConfigError) Unwrap() error {
+	return o.err
+}
+
+// createOIDCConfig creates an oidc config if one does not already exist. Options
+// can optionally be provided to register a customer managed oidc issuer instead
+func (r *Provider) CreateOIDCConfig(ctx context.Context, prefix, installerRoleArn string, args ...*OIDCConfigOptions) (string, error) {
 	const action = "create"
 
 	if prefix == "" || installerRoleArn == "" {
 		return "", &oidcConfigError{action: action, err: errors.New("some parameters are undefined")}
 	}
 
-	oidcConfig, err := r.oidcConfigLookup(ctx, prefix)
+	options := &OIDCConfigOptions{}
+	if len(args) == 1 && args[0] != nil {
+		options = args[0]
+	}
+
+	if err := options.validate(); err != nil {
+		return "", &oidcConfigError{action: action, err: err}
+	}
+
+	oidcConfig, err := r.oidcConfigLookup(ctx, prefix, options.IssuerURL)
 	if oidcConfig != nil {
 		r.log.Info("OIDC config id already exist", prefixLoggerKey, prefix, oidcConfigIDLoggerKey, oidcConfig.ID(),
 			ocmEnvironmentLoggerKey, r.ocmEnvironment)
@@ -40,21 +87,12 @@ func (r *Provider) CreateOIDCConfig(ctx context.Context, prefix, installerRoleAr
 		return "", &oidcConfigError{action: action, err: err}
 	}
 
-	commandArgs := []string{
-		"create", "oidc-config",
-		"--output", "json",
-		"--mode", "auto",
-		"--yes",
-	}
-
-	// The OIDC needs to be `--managed` for FedRamp Which does not support these flags: --prefix, --installer-role-arn
-	if !r.fedRamp {
-		commandArgs = append(commandArgs, "--managed=false")
-		commandArgs = append(commandArgs, "--prefix", prefix)
-		commandArgs = append(commandArgs, "--installer-role-arn", installerRoleArn)
+	commandArgs, err := r.oidcConfigCommandArgs(prefix, installerRoleArn, options)
+	if err != nil {
+		return "", &oidcConfigError{action: action, err: err}
 	}
 
-	r.log.Info("Creating OIDC config", prefixLoggerKey, prefix, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+	r.log.Info("Creating OIDC config", prefixLoggerKey, prefix, "issuer_url", options.IssuerURL, ocmEnvironmentLoggerKey, r.ocmEnvironment)
 
 	stdout, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
 	if err != nil {
@@ -71,6 +109,42 @@ func (r *Provider) CreateOIDCConfig(ctx context.Context, prefix, installerRoleAr
 	return fmt.Sprint(output["id"]), nil
 }
 
+// oidcConfigCommandArgs builds the rosa command arguments to create or register the oidc config
+func (r *Provider) oidcConfigCommandArgs(prefix, installerRoleArn string, options *OIDCConfigOptions) ([]string, error) {
+	if options.IssuerURL != "" {
+		if r.fedRamp {
+			return nil, errors.New("external oidc issuers are not supported for fedramp")
+		}
+
+		return []string{
+			"register", "oidc-config",
+			"--output", "json",
+			"--mode", "auto",
+			"--managed=false",
+			"--issuer-url", options.IssuerURL,
+			"--secret-arn", options.SecretARN,
+			"--role-arn", installerRoleArn,
+			"--yes",
+		}, nil
+	}
+
+	commandArgs := []string{
+		"create", "oidc-config",
+		"--output", "json",
+		"--mode", "auto",
+		"--yes",
+	}
+
+	// The OIDC needs to be `--managed` for FedRamp Which does not support these flags: --prefix, --installer-role-arn
+	if !r.fedRamp {
+		commandArgs = append(commandArgs, "--managed=false")
+		commandArgs = append(commandArgs, "--prefix", prefix)
+		commandArgs = append(commandArgs, "--installer-role-arn", installerRoleArn)
+	}
+
+	return commandArgs, nil
+}
+
 // deleteOIDCConfig deletes the oidc config using the id
 func (r *Provider) DeleteOIDCConfig(ctx context.Context, oidcConfigID string) error {
 	commandArgs := []string{
@@ -92,14 +166,22 @@ func (r *Provider) DeleteOIDCConfig(ctx context.Context, oidcConfigID string) er
 	return nil
 }
 
-// oidcConfigLookup checks if an oidc config already exists using the provided prefix
-func (r *Provider) oidcConfigLookup(ctx context.Context, prefix string) (*clustersmgmtv1.OidcConfig, error) {
+// oidcConfigLookup checks if an oidc config already exists using the provided
+// issuer url when set, otherwise the prefix
+func (r *Provider) oidcConfigLookup(ctx context.Context, prefix, issuerURL string) (*clustersmgmtv1.OidcConfig, error) {
 	response, err := r.ClustersMgmt().V1().OidcConfigs().List().SendContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve oidc configs from ocm: %v", err)
 	}
 
 	for _, oidcConfig := range response.Items().Slice() {
+		if issuerURL != "" {
+			if strings.TrimSuffix(oidcConfig.IssuerUrl(), "/") == strings.TrimSuffix(issuerURL, "/") {
+				return oidcConfig, nil
+			}
+			continue
+		}
+
 		if strings.Contains(oidcConfig.SecretArn(), prefix) {
 			return oidcConfig, nil
 		}
@@ -0,0 +1,53 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("oidc config", func() {
+	const installerRoleARN = "arn:aws:iam::123456789012:role/test-Installer-Role"
+
+	It("should create an unmanaged oidc config by default", func() {
+		args, err := (&Provider{}).oidcConfigCommandArgs("test", installerRoleARN, &OIDCConfigOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(args[:2]).Should(Equal([]string{"create", "oidc-config"}))
+		Expect(args).Should(ContainElements("--managed=false", "--prefix", "test", "--installer-role-arn", installerRoleARN))
+	})
+
+	It("should register an external oidc issuer", func() {
+		options := &OIDCConfigOptions{
+			IssuerURL: "https://d1234.cloudfront.net/test",
+			SecretARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-key",
+		}
+		Expect(options.validate()).Should(Succeed())
+
+		args, err := (&Provider{}).oidcConfigCommandArgs("test", installerRoleARN, options)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(args[:2]).Should(Equal([]string{"register", "oidc-config"}))
+		Expect(args).Should(ContainElements(
+			"--issuer-url", options.IssuerURL,
+			"--secret-arn", options.SecretARN,
+			"--role-arn", installerRoleARN,
+		))
+		Expect(args).ShouldNot(ContainElement("--prefix"))
+	})
+
+	It("should reject external oidc issuers for fedramp", func() {
+		_, err := (&Provider{fedRamp: true}).oidcConfigCommandArgs("test", installerRoleARN, &OIDCConfigOptions{
+			IssuerURL: "https://d1234.cloudfront.net/test",
+			SecretARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-key",
+		})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	DescribeTable("should reject invalid external issuer options",
+		func(options *OIDCConfigOptions) {
+			Expect(options.validate()).ShouldNot(Succeed())
+		},
+		Entry("http issuer", &OIDCConfigOptions{IssuerURL: "http://d1234.cloudfront.net/test", SecretARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-key"}),
+		Entry("missing host", &OIDCConfigOptions{IssuerURL: "https:///test", SecretARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-key"}),
+		Entry("query", &OIDCConfigOptions{IssuerURL: "https://d1234.cloudfront.net/test?a=b", SecretARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-key"}),
+		Entry("missing secret arn", &OIDCConfigOptions{IssuerURL: "https://d1234.cloudfront.net/test"}),
+	)
+})
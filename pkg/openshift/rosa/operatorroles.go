@@ -2,10 +2,54 @@ package rosa
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+	"time"
 )
 
+// iamRoles represents the iam operations used to inspect operator roles
+type iamRoles interface {
+	// listRoleNames returns the names of the roles starting with the prefix
+	listRoleNames(ctx context.Context, prefix string) ([]string, error)
+	// getRoleTrustPolicy returns the roles assume role policy document
+	getRoleTrustPolicy(ctx context.Context, roleName string) (string, error)
+}
+
+// awsCLIRoles implements iamRoles using the aws cli
+type awsCLIRoles struct {
+	provider *Provider
+}
+
+// listRoleNames returns the names of the roles starting with the prefix
+func (a *awsCLIRoles) listRoleNames(ctx context.Context, prefix string) ([]string, error) {
+	stdout, err := a.provider.runAWSCommand(ctx, "iam", "list-roles",
+		"--query", fmt.Sprintf("Roles[?starts_with(RoleName, '%s-')].RoleName", prefix),
+		"--output", "json",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var roleNames []string
+	if err = json.Unmarshal([]byte(stdout), &roleNames); err != nil {
+		return nil, fmt.Errorf("failed to parse list roles output: %v", err)
+	}
+
+	return roleNames, nil
+}
+
+// getRoleTrustPolicy returns the roles assume role policy document
+func (a *awsCLIRoles) getRoleTrustPolicy(ctx context.Context, roleName string) (string, error) {
+	return a.provider.runAWSCommand(ctx, "iam", "get-role",
+		"--role-name", roleName,
+		"--query", "Role.AssumeRolePolicyDocument",
+		"--output", "json",
+	)
+}
+
 // operatorRoleError represents the custom error
 type operatorRoleError struct {
 	action string
@@ -17,7 +61,13 @@ func (o *operatorRoleError) Error() string {
 	return fmt.Sprintf("%s operator role failed: %v", o.action, o.err)
 }
 
-// deleteOIDCConfigProvider deletes the oidc config provider associated to the cluster
+// Unwrap returns the error wrapped by operatorRoleError
+func (o *operatorRoleError) Unwrap() error {
+	return o.err
+}
+
+// deleteOperatorRoles deletes the operator roles of the cluster, by prefix when
+// the cluster uses an oidc config or no longer exists
 func (r *Provider) deleteOperatorRoles(ctx context.Context, clusterID, clusterPrefix, oidcConfigID string) error {
 	commandArgs := []string{
 		"delete", "operator-roles",
@@ -25,7 +75,7 @@ func (r *Provider) deleteOperatorRoles(ctx context.Context, clusterID, clusterPr
 		"--yes",
 	}
 
-	if oidcConfigID != "" {
+	if oidcConfigID != "" || clusterID == "" {
 		commandArgs = append(commandArgs, "--prefix", clusterPrefix)
 	} else {
 		commandArgs = append(commandArgs, "--cluster", clusterID)
@@ -42,3 +92,117 @@ func (r *Provider) deleteOperatorRoles(ctx context.Context, clusterID, clusterPr
 
 	return nil
 }
+
+// operatorRolesInUse returns true when any cluster in ocm still uses operator roles with the prefix
+func (r *Provider) operatorRolesInUse(ctx context.Context, operatorRolesPrefix string) (bool, error) {
+	var total int
+	err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := r.ClustersMgmt().V1().Clusters().List().
+			Search(fmt.Sprintf("aws.sts.operator_role_prefix = '%s'", operatorRolesPrefix)).
+			Size(1).
+			SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		total = response.Total()
+		return nil
+	})
+	return total > 0, err
+}
+
+// deleteUnusedOperatorRoles deletes the clusters operator roles unless other
+// clusters still use operator roles with the same prefix
+func (r *Provider) deleteUnusedOperatorRoles(ctx context.Context, clusterID, operatorRolesPrefix, oidcConfigID string, inUse func(context.Context, string) (bool, error)) error {
+	if operatorRolesPrefix != "" {
+		used, err := inUse(ctx, operatorRolesPrefix)
+		if err != nil {
+			return &operatorRoleError{action: "delete", err: fmt.Errorf("failed to check whether operator roles with prefix %q are in use: %v", operatorRolesPrefix, err)}
+		}
+		if used {
+			r.log.Info("Skipping operator roles deletion, prefix is used by other clusters", clusterIDLoggerKey, clusterID,
+				prefixLoggerKey, operatorRolesPrefix, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+			return nil
+		}
+	}
+
+	return r.deleteOperatorRoles(ctx, clusterID, operatorRolesPrefix, oidcConfigID)
+}
+
+// WaitForOperatorRolesTrustPolicy waits for the trust policies of the operator
+// roles with the prefix to reference the oidc configs provider. IAM changes are
+// eventually consistent and creating a cluster before they propagate fails
+func (r *Provider) WaitForOperatorRolesTrustPolicy(ctx context.Context, operatorRolesPrefix, oidcConfigID string, timeout time.Duration) error {
+	const action = "validate trust policy"
+
+	var issuerURL string
+	err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := r.ClustersMgmt().V1().OidcConfigs().OidcConfig(oidcConfigID).Get().SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		issuerURL = response.Body().IssuerUrl()
+		return nil
+	})
+	if err != nil {
+		return &operatorRoleError{action: action, err: fmt.Errorf("failed to get oidc config %q: %v", oidcConfigID, err)}
+	}
+
+	if err = r.waitForOperatorRolesTrustPolicy(ctx, &awsCLIRoles{provider: r}, operatorRolesPrefix, issuerURL, timeout); err != nil {
+		return &operatorRoleError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// waitForOperatorRolesTrustPolicy waits until every operator role with the prefix trusts the issuers oidc provider
+func (r *Provider) waitForOperatorRolesTrustPolicy(ctx context.Context, roles iamRoles, operatorRolesPrefix, issuerURL string, timeout time.Duration) error {
+	if operatorRolesPrefix == "" || issuerURL == "" {
+		return errors.New("operator roles prefix and issuer url are required")
+	}
+
+	// trust policies reference the oidc provider by arn, which ends with the issuer url without the scheme
+	oidcProvider := fmt.Sprintf("oidc-provider/%s", strings.TrimSuffix(strings.TrimPrefix(issuerURL, "https://"), "/"))
+
+	r.log.Info("Waiting for operator roles trust policy", prefixLoggerKey, operatorRolesPrefix, "oidc_provider", oidcProvider,
+		timeoutLoggerKey, timeout.Round(time.Second).String())
+
+	var lastErr error
+	err := r.waitUntil(ctx, 10*time.Second, timeout, func(ctx context.Context) (bool, error) {
+		roleNames, err := roles.listRoleNames(ctx, operatorRolesPrefix)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+
+		if len(roleNames) == 0 {
+			lastErr = fmt.Errorf("no operator roles found with prefix %q", operatorRolesPrefix)
+			return false, nil
+		}
+
+		for _, roleName := range roleNames {
+			trustPolicy, err := roles.getRoleTrustPolicy(ctx, roleName)
+			if err != nil {
+				lastErr = err
+				return false, nil
+			}
+
+			if !strings.Contains(trustPolicy, oidcProvider) {
+				lastErr = fmt.Errorf("operator role %q trust policy does not reference %q", roleName, oidcProvider)
+				r.log.Info("Operator role trust policy not propagated", prefixLoggerKey, operatorRolesPrefix, "role_name", roleName)
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("%w: %v", err, lastErr)
+		}
+		return err
+	}
+
+	r.log.Info("Operator roles trust policy propagated!", prefixLoggerKey, operatorRolesPrefix)
+
+	return nil
+}
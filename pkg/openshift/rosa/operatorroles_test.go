@@ -0,0 +1,147 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+// fakeIAMRoles returns the trust policies in order for each get role call
+type fakeIAMRoles struct {
+	roleNames     []string
+	trustPolicies []string
+	getRoleErr    error
+	calls         int
+}
+
+func (f *fakeIAMRoles) listRoleNames(context.Context, string) ([]string, error) {
+	return f.roleNames, nil
+}
+
+func (f *fakeIAMRoles) getRoleTrustPolicy(context.Context, string) (string, error) {
+	if f.getRoleErr != nil {
+		return "", f.getRoleErr
+	}
+	policy := f.trustPolicies[min(f.calls, len(f.trustPolicies)-1)]
+	f.calls++
+	return policy, nil
+}
+
+var _ = Describe("operator roles trust policy", func() {
+	const (
+		issuerURL        = "https://oidc.example.com/abc123"
+		propagatedPolicy = `{"Statement": [{"Principal": {"Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc123"}}]}`
+		stalePolicy      = `{"Statement": [{"Principal": {"Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/old"}}]}`
+	)
+
+	var provider *Provider
+
+	BeforeEach(func() {
+		provider = &Provider{log: logr.Discard(), PollStrategy: poll.Strategy{Interval: time.Millisecond}}
+	})
+
+	It("should wait for the trust policy to propagate", func(ctx context.Context) {
+		roles := &fakeIAMRoles{
+			roleNames:     []string{"test-openshift-ingress-operator-cloud-credentials"},
+			trustPolicies: []string{stalePolicy, stalePolicy, propagatedPolicy},
+		}
+		Expect(provider.waitForOperatorRolesTrustPolicy(ctx, roles, "test", issuerURL, time.Second)).Should(Succeed())
+		Expect(roles.calls).Should(Equal(3))
+	})
+
+	It("should time out when the trust policy never references the oidc provider", func(ctx context.Context) {
+		roles := &fakeIAMRoles{
+			roleNames:     []string{"test-openshift-ingress-operator-cloud-credentials"},
+			trustPolicies: []string{stalePolicy},
+		}
+		err := provider.waitForOperatorRolesTrustPolicy(ctx, roles, "test", issuerURL, 20*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("does not reference")))
+	})
+
+	It("should surface get role errors on timeout", func(ctx context.Context) {
+		roles := &fakeIAMRoles{
+			roleNames:  []string{"test-openshift-ingress-operator-cloud-credentials"},
+			getRoleErr: errors.New("NoSuchEntity"),
+		}
+		err := provider.waitForOperatorRolesTrustPolicy(ctx, roles, "test", issuerURL, 20*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("NoSuchEntity")))
+	})
+
+	It("should require operator roles to exist", func(ctx context.Context) {
+		err := provider.waitForOperatorRolesTrustPolicy(ctx, &fakeIAMRoles{}, "test", issuerURL, 20*time.Millisecond)
+		Expect(err).Should(MatchError(ContainSubstring("no operator roles found")))
+	})
+})
+
+var _ = Describe("shared operator roles", func() {
+	var (
+		provider    *Provider
+		commandFile string
+	)
+
+	BeforeEach(func() {
+		commandFile = filepath.Join(GinkgoT().TempDir(), "commands")
+
+		// fake rosa cli recording the commands it runs
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\necho \"$@\" >> "+commandFile+"\n"), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+	})
+
+	commands := func() string {
+		data, err := os.ReadFile(commandFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return ""
+		}
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("should create the cluster with the shared prefix", func() {
+		options := &CreateClusterOptions{
+			ClusterName:         "test",
+			Version:             "4.15.0",
+			STS:                 true,
+			OperatorRolesPrefix: "shared",
+		}
+		Expect(provider.createClusterCommandArgs(options)).Should(ContainElements("--operator-roles-prefix", "shared"))
+	})
+
+	It("should not delete operator roles still used by other clusters", func(ctx context.Context) {
+		err := provider.deleteUnusedOperatorRoles(ctx, "123", "shared", "abc", func(_ context.Context, prefix string) (bool, error) {
+			Expect(prefix).Should(Equal("shared"))
+			return true, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(commands()).Should(BeEmpty())
+	})
+
+	It("should delete operator roles no longer used", func(ctx context.Context) {
+		err := provider.deleteUnusedOperatorRoles(ctx, "123", "shared", "abc", func(context.Context, string) (bool, error) {
+			return false, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(commands()).Should(ContainSubstring("delete operator-roles --mode auto --yes --prefix shared"))
+	})
+
+	It("should not delete operator roles when their use can not be determined", func(ctx context.Context) {
+		err := provider.deleteUnusedOperatorRoles(ctx, "123", "shared", "abc", func(context.Context, string) (bool, error) {
+			return false, errors.New("ocm unavailable")
+		})
+		Expect(err).Should(HaveOccurred())
+		Expect(commands()).Should(BeEmpty())
+	})
+})
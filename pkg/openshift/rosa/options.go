@@ -0,0 +1,67 @@
+package rosa
+
+import (
+	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+// Option configures the provider constructed by NewWithOptions
+type Option func(*providerOptions)
+
+// providerOptions holds the settings used to construct the provider
+type providerOptions struct {
+	token          string
+	clientID       string
+	clientSecret   string
+	ocmEnvironment ocmclient.Environment
+	awsCredentials *awscloud.AWSCredentials
+	rosaBinary     string
+}
+
+// newProviderOptions returns the provider options with the options applied
+func newProviderOptions(opts ...Option) *providerOptions {
+	options := &providerOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithToken authenticates with ocm using the offline token
+func WithToken(token string) Option {
+	return func(o *providerOptions) {
+		o.token = token
+	}
+}
+
+// WithClientCredentials authenticates with ocm using the service account
+// client id and secret, they take precedence over the token
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(o *providerOptions) {
+		o.clientID = clientID
+		o.clientSecret = clientSecret
+	}
+}
+
+// WithOCMEnvironment sets the ocm environment, it is required
+func WithOCMEnvironment(ocmEnvironment ocmclient.Environment) Option {
+	return func(o *providerOptions) {
+		o.ocmEnvironment = ocmEnvironment
+	}
+}
+
+// WithAWSCredentials sets the aws credentials, they are read from the
+// environment when unset
+func WithAWSCredentials(awsCredentials *awscloud.AWSCredentials) Option {
+	return func(o *providerOptions) {
+		o.awsCredentials = awsCredentials
+	}
+}
+
+// WithRosaBinary uses the rosa cli at the path instead of looking it up or
+// downloading it
+func WithRosaBinary(rosaBinary string) Option {
+	return func(o *providerOptions) {
+		o.rosaBinary = rosaBinary
+	}
+}
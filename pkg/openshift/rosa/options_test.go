@@ -0,0 +1,46 @@
+package rosa
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("provider options", func() {
+	It("should apply the options", func() {
+		credentials := &awscloud.AWSCredentials{Region: "us-east-1"}
+		options := newProviderOptions(
+			WithToken("token"),
+			WithClientCredentials("id", "secret"),
+			WithOCMEnvironment(ocmclient.Stage),
+			WithAWSCredentials(credentials),
+			WithRosaBinary("/usr/local/bin/rosa"),
+		)
+
+		Expect(options.token).Should(Equal("token"))
+		Expect(options.clientID).Should(Equal("id"))
+		Expect(options.clientSecret).Should(Equal("secret"))
+		Expect(options.ocmEnvironment).Should(Equal(ocmclient.Stage))
+		Expect(options.awsCredentials).Should(BeIdenticalTo(credentials))
+		Expect(options.rosaBinary).Should(Equal("/usr/local/bin/rosa"))
+	})
+
+	It("should require the ocm environment and credentials", func(ctx context.Context) {
+		_, err := NewWithOptions(ctx, logr.Discard(), WithToken("token"))
+		Expect(err).Should(MatchError(ContainSubstring("undefined")))
+
+		_, err = NewWithOptions(ctx, logr.Discard(), WithOCMEnvironment(ocmclient.Stage), WithClientCredentials("id", ""))
+		Expect(err).Should(MatchError(ContainSubstring("undefined")))
+	})
+
+	It("should validate the rosa binary runs", func(ctx context.Context) {
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		_, err := NewWithOptions(ctx, logr.Discard(), WithToken("token"), WithOCMEnvironment(ocmclient.Stage), WithRosaBinary(rosaBinary))
+		Expect(err).Should(MatchError(ContainSubstring(rosaBinary)))
+	})
+})
@@ -0,0 +1,255 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// operatorRoleNamespaces are the namespaces operator role names include after their prefix
+var operatorRoleNamespaces = []string{"-openshift-", "-kube-system-"}
+
+// purgeSteps are the operations used to purge a cluster and its dependencies
+type purgeSteps struct {
+	findCluster        func(ctx context.Context, clusterName string) (*clustersmgmtv1.Cluster, error)
+	deleteCluster      func(ctx context.Context, cluster *clustersmgmtv1.Cluster) error
+	roles              iamRoles
+	operatorRolesInUse func(ctx context.Context, prefix string) (bool, error)
+	oidcConfigIDs      func(ctx context.Context, prefix string) ([]string, error)
+	oidcConfigInUse    func(ctx context.Context, oidcConfigID string) (bool, error)
+	accountRolesExist  func(ctx context.Context, prefix string) (bool, error)
+	deleteVPC          func(ctx context.Context, clusterName string) error
+}
+
+// PurgeCluster deletes the cluster when it exists and then sweeps the account
+// roles, operator roles, oidc configs and vpc named after it. The vpc is only
+// destroyed from the terraform state kept for the cluster when it was created
+// with the default working directory. Operator roles and
+// oidc configs still used by other clusters are kept. The sweep is best effort,
+// every step runs and their errors are returned together, but it is skipped when
+// the cluster fails to delete. Used to clean up after a failed run left
+// dependencies without a matching cluster
+func (r *Provider) PurgeCluster(ctx context.Context, clusterName string) error {
+	r = r.withOperationID()
+
+	return r.purgeCluster(ctx, clusterName, purgeSteps{
+		findCluster: r.findCluster,
+		deleteCluster: func(ctx context.Context, cluster *clustersmgmtv1.Cluster) error {
+			if err := r.deleteCluster(ctx, cluster.ID()); err != nil {
+				return err
+			}
+			return r.waitForClusterToBeDeleted(ctx, cluster.Name(), os.TempDir(), 30*time.Minute)
+		},
+		roles:              &awsCLIRoles{provider: r},
+		operatorRolesInUse: r.operatorRolesInUse,
+		oidcConfigIDs:      r.oidcConfigIDs,
+		oidcConfigInUse:    r.oidcConfigInUse,
+		accountRolesExist: func(ctx context.Context, prefix string) (bool, error) {
+			accountRoles, err := r.getAccountRoles(ctx, prefix, "")
+			return accountRoles != nil, err
+		},
+		deleteVPC: func(ctx context.Context, clusterName string) error {
+			return r.purgeVPC(ctx, clusterName, vpcWorkingDir(os.TempDir(), clusterName))
+		},
+	})
+}
+
+// purgeCluster deletes the cluster and then runs each sweep step, continuing
+// past failures
+func (r *Provider) purgeCluster(ctx context.Context, clusterName string, steps purgeSteps) error {
+	const action = "purge"
+
+	if clusterName == "" {
+		return &clusterError{action: action, err: errors.New("cluster name is required")}
+	}
+
+	r.log.Info("Purging cluster and its dependencies", clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	cluster, err := steps.findCluster(ctx, clusterName)
+	switch {
+	case errors.Is(err, ErrClusterNotFound):
+		r.log.Info("Cluster not found, purging its dependencies", clusterNameLoggerKey, clusterName)
+	case err != nil:
+		// the cluster may still exist and use the dependencies
+		return &clusterError{action: action, err: err}
+	default:
+		if err = steps.deleteCluster(ctx, cluster); err != nil {
+			// the dependencies are still used by the cluster
+			return &clusterError{action: action, err: err}
+		}
+	}
+
+	var errs []error
+
+	roleNames, err := steps.roles.listRoleNames(ctx, clusterName)
+	if err != nil {
+		errs = append(errs, &operatorRoleError{action: "list", err: err})
+	}
+
+	for _, prefix := range clusterOperatorRolePrefixes(clusterName, roleNames) {
+		inUse, err := steps.operatorRolesInUse(ctx, prefix)
+		if err != nil {
+			errs = append(errs, &operatorRoleError{action: "delete", err: fmt.Errorf("failed to check whether operator roles with prefix %q are in use: %v", prefix, err)})
+			continue
+		}
+		if inUse {
+			r.log.Info("Skipping operator roles deletion, prefix is used by other clusters", prefixLoggerKey, prefix)
+			continue
+		}
+		if err = r.deleteOperatorRoles(ctx, "", prefix, ""); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	oidcConfigIDs, err := steps.oidcConfigIDs(ctx, clusterName)
+	if err != nil {
+		errs = append(errs, &oidcConfigError{action: "list", err: err})
+	}
+
+	for _, oidcConfigID := range oidcConfigIDs {
+		inUse, err := steps.oidcConfigInUse(ctx, oidcConfigID)
+		if err != nil {
+			errs = append(errs, &oidcConfigError{action: "delete", err: fmt.Errorf("failed to check whether oidc config %q is in use: %v", oidcConfigID, err)})
+			continue
+		}
+		if inUse {
+			r.log.Info("Skipping oidc config deletion, it is used by other clusters", oidcConfigIDLoggerKey, oidcConfigID)
+			continue
+		}
+		if err = r.deleteOIDCConfigProvider(ctx, "", oidcConfigID); err != nil {
+			errs = append(errs, err)
+		}
+		if err = r.DeleteOIDCConfig(ctx, oidcConfigID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if isDefaultAccountRolesPrefix(clusterName) {
+		r.log.Info("Skipping shared account roles deletion", prefixLoggerKey, clusterName)
+	} else if exist, err := steps.accountRolesExist(ctx, clusterName); err != nil {
+		errs = append(errs, &accountRolesError{action: "get", err: err})
+	} else if exist {
+		if err = r.DeleteAccountRoles(ctx, clusterName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err = steps.deleteVPC(ctx, clusterName); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 0 {
+		return &clusterError{action: action, err: errors.Join(errs...)}
+	}
+
+	r.log.Info("Cluster and its dependencies purged!", clusterNameLoggerKey, clusterName, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return nil
+}
+
+// purgeVPC destroys the vpc tracked by the terraform state in the clusters vpc
+// working directory, nothing is destroyed when the directory holds no state
+func (r *Provider) purgeVPC(ctx context.Context, clusterName, workingDir string) error {
+	if _, err := os.Stat(filepath.Join(workingDir, "terraform.tfstate")); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			r.log.Info("Skipping vpc deletion, no terraform state found for the cluster", clusterNameLoggerKey, clusterName, terraformWorkingDirLoggerKey, workingDir)
+			return nil
+		}
+		return &vpcError{action: "delete", err: err}
+	}
+
+	return r.deleteVPC(ctx, clusterName, r.awsCredentials.Region, workingDir)
+}
+
+// clusterOperatorRolePrefixes returns the operator role prefixes generated for
+// the cluster, <cluster name>-<random suffix>. Prefixes of clusters whose name
+// starts with the cluster name, e.g. test-2 for test, are excluded
+func clusterOperatorRolePrefixes(clusterName string, roleNames []string) []string {
+	var prefixes []string
+	for _, prefix := range operatorRolePrefixes(roleNames) {
+		suffix, found := strings.CutPrefix(prefix, clusterName+"-")
+		if found && suffix != "" && !strings.Contains(suffix, "-") {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// operatorRolePrefixes returns the unique operator role prefixes of the role
+// names, operator roles are named <prefix>-<namespace>-<name>
+func operatorRolePrefixes(roleNames []string) []string {
+	var prefixes []string
+	seen := map[string]bool{}
+
+	for _, roleName := range roleNames {
+		for _, namespace := range operatorRoleNamespaces {
+			index := strings.Index(roleName, namespace)
+			if index <= 0 {
+				continue
+			}
+			prefix := roleName[:index]
+			if !seen[prefix] {
+				seen[prefix] = true
+				prefixes = append(prefixes, prefix)
+			}
+			break
+		}
+	}
+
+	return prefixes
+}
+
+// oidcConfigIDs returns the ids of the oidc configs created with the prefix,
+// their secret is named <prefix>-<suffix>
+func (r *Provider) oidcConfigIDs(ctx context.Context, prefix string) ([]string, error) {
+	var ids []string
+	err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := r.ClustersMgmt().V1().OidcConfigs().List().SendContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		ids = nil
+		for _, oidcConfig := range response.Items().Slice() {
+			if strings.HasPrefix(oidcConfigSecretName(oidcConfig.SecretArn()), prefix+"-") {
+				ids = append(ids, oidcConfig.ID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve oidc configs from ocm: %v", err)
+	}
+
+	return ids, nil
+}
+
+// oidcConfigSecretName returns the name of the secret from its arn,
+// arn:aws:secretsmanager:<region>:<account>:secret:<name>
+func oidcConfigSecretName(secretARN string) string {
+	_, name, _ := strings.Cut(secretARN, ":secret:")
+	return name
+}
+
+// oidcConfigInUse returns true when a cluster uses the oidc config
+func (r *Provider) oidcConfigInUse(ctx context.Context, oidcConfigID string) (bool, error) {
+	var total int
+	err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+		response, err := r.ClustersMgmt().V1().Clusters().List().
+			Search(fmt.Sprintf("aws.sts.oidc_config.id = '%s'", oidcConfigID)).
+			Size(1).
+			SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		total = response.Total()
+		return nil
+	})
+	return total > 0, err
+}
@@ -0,0 +1,199 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("purge cluster", func() {
+	var (
+		provider    *Provider
+		commandFile string
+		steps       purgeSteps
+		deletedVPC  string
+	)
+
+	BeforeEach(func() {
+		commandFile = filepath.Join(GinkgoT().TempDir(), "commands")
+
+		// fake rosa cli recording the commands it runs
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\necho \"$@\" >> "+commandFile+"\n"), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+
+		deletedVPC = ""
+		steps = purgeSteps{
+			findCluster: func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+				return nil, fmt.Errorf("%w: %q", ErrClusterNotFound, "test")
+			},
+			deleteCluster: func(context.Context, *clustersmgmtv1.Cluster) error {
+				Fail("cluster should not be deleted")
+				return nil
+			},
+			roles: &fakeIAMRoles{roleNames: []string{
+				"test-a1b2-openshift-ingress-operator-cloud-credentials",
+				"test-a1b2-kube-system-kube-controller-manager",
+				"test-Installer-Role",
+				"test-2-c3d4-openshift-ingress-operator-cloud-credentials",
+			}},
+			operatorRolesInUse: func(context.Context, string) (bool, error) { return false, nil },
+			oidcConfigIDs:      func(context.Context, string) ([]string, error) { return []string{"abc"}, nil },
+			oidcConfigInUse:    func(context.Context, string) (bool, error) { return false, nil },
+			accountRolesExist:  func(context.Context, string) (bool, error) { return true, nil },
+			deleteVPC: func(_ context.Context, clusterName string) error {
+				deletedVPC = clusterName
+				return nil
+			},
+		}
+	})
+
+	commands := func() string {
+		data, err := os.ReadFile(commandFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return ""
+		}
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("should delete the dependencies of a cluster that no longer exists", func(ctx context.Context) {
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(Succeed())
+
+		Expect(commands()).Should(And(
+			ContainSubstring("delete operator-roles --mode auto --yes --prefix test-a1b2\n"),
+			ContainSubstring("delete oidc-provider --mode auto --yes --oidc-config-id abc\n"),
+			ContainSubstring("delete oidc-config --mode auto --oidc-config-id abc --yes\n"),
+			ContainSubstring("delete account-roles --prefix test --mode auto --yes\n"),
+		))
+		Expect(deletedVPC).Should(Equal("test"))
+		Expect(commands()).ShouldNot(ContainSubstring("--prefix test-2-c3d4"))
+	})
+
+	It("should delete the cluster when it exists", func(ctx context.Context) {
+		var deleted string
+		steps.findCluster = func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+			return clustersmgmtv1.NewCluster().ID("123").Name("test").Build()
+		}
+		steps.deleteCluster = func(_ context.Context, cluster *clustersmgmtv1.Cluster) error {
+			deleted = cluster.ID()
+			return nil
+		}
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(Succeed())
+		Expect(deleted).Should(Equal("123"))
+	})
+
+	It("should keep operator roles still used by other clusters", func(ctx context.Context) {
+		steps.operatorRolesInUse = func(context.Context, string) (bool, error) { return true, nil }
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(Succeed())
+		Expect(commands()).ShouldNot(ContainSubstring("delete operator-roles"))
+	})
+
+	It("should keep oidc configs still used by other clusters", func(ctx context.Context) {
+		steps.oidcConfigInUse = func(context.Context, string) (bool, error) { return true, nil }
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(Succeed())
+		Expect(commands()).ShouldNot(ContainSubstring("delete oidc-"))
+	})
+
+	It("should not sweep the dependencies when the cluster fails to delete", func(ctx context.Context) {
+		steps.findCluster = func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+			return clustersmgmtv1.NewCluster().ID("123").Name("test").Build()
+		}
+		steps.deleteCluster = func(context.Context, *clustersmgmtv1.Cluster) error {
+			return errors.New("uninstall failed")
+		}
+		steps.deleteVPC = func(context.Context, string) error {
+			Fail("vpc should not be deleted")
+			return nil
+		}
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(MatchError(ContainSubstring("uninstall failed")))
+		Expect(commands()).Should(BeEmpty())
+	})
+
+	It("should not sweep the dependencies when the cluster lookup fails", func(ctx context.Context) {
+		steps.findCluster = func(context.Context, string) (*clustersmgmtv1.Cluster, error) {
+			return nil, errors.New("status is 401")
+		}
+		steps.deleteVPC = func(context.Context, string) error {
+			Fail("vpc should not be deleted")
+			return nil
+		}
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(MatchError(ContainSubstring("status is 401")))
+		Expect(commands()).Should(BeEmpty())
+	})
+
+	It("should skip account roles that do not exist", func(ctx context.Context) {
+		steps.accountRolesExist = func(context.Context, string) (bool, error) { return false, nil }
+
+		Expect(provider.purgeCluster(ctx, "test", steps)).Should(Succeed())
+		Expect(commands()).ShouldNot(ContainSubstring("delete account-roles"))
+	})
+
+	It("should continue past failed steps and return their errors", func(ctx context.Context) {
+		steps.oidcConfigIDs = func(context.Context, string) ([]string, error) { return nil, errors.New("ocm unavailable") }
+		steps.deleteVPC = func(context.Context, string) error { return errors.New("terraform destroy failed") }
+
+		err := provider.purgeCluster(ctx, "test", steps)
+		Expect(err).Should(MatchError(ContainSubstring("ocm unavailable")))
+		Expect(err).Should(MatchError(ContainSubstring("terraform destroy failed")))
+		Expect(commands()).Should(ContainSubstring("delete account-roles --prefix test"))
+	})
+})
+
+var _ = Describe("purge vpc", func() {
+	It("should skip the vpc without terraform state for the cluster", func(ctx context.Context) {
+		provider := &Provider{awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"}, log: logr.Discard()}
+		workingDir := vpcWorkingDir(GinkgoT().TempDir(), "test")
+
+		Expect(workingDir).Should(HaveSuffix("/test-vpc"))
+		Expect(provider.purgeVPC(ctx, "test", workingDir)).Should(Succeed())
+	})
+})
+
+var _ = Describe("operator role prefixes", func() {
+	It("should return the unique prefixes of operator roles", func() {
+		Expect(operatorRolePrefixes([]string{
+			"test-a1b2-openshift-ingress-operator-cloud-credentials",
+			"test-a1b2-openshift-image-registry-installer-cloud-credentials",
+			"test-c3d4-kube-system-kube-controller-manager",
+			"test-Installer-Role",
+			"test-HCP-ROSA-Worker-Role",
+		})).Should(Equal([]string{"test-a1b2", "test-c3d4"}))
+	})
+})
+
+var _ = Describe("cluster operator role prefixes", func() {
+	It("should only return the prefixes generated for the cluster", func() {
+		Expect(clusterOperatorRolePrefixes("test", []string{
+			"test-a1b2-openshift-ingress-operator-cloud-credentials",
+			"test-2-c3d4-openshift-ingress-operator-cloud-credentials",
+			"testing-e5f6-kube-system-kube-controller-manager",
+		})).Should(Equal([]string{"test-a1b2"}))
+	})
+})
+
+var _ = Describe("oidc config secret name", func() {
+	It("should return the name of the secret", func() {
+		Expect(oidcConfigSecretName("arn:aws:secretsmanager:us-east-1:123456789012:secret:test-a1b2-oidc-private-key-AbCdEf")).
+			Should(Equal("test-a1b2-oidc-private-key-AbCdEf"))
+		Expect(oidcConfigSecretName("")).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,52 @@
+package rosa
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// quotaPatterns match the aws quota failures reported by the rosa cli and
+// terraform, the first group captures the exhausted resource
+var quotaPatterns = []*regexp.Regexp{
+	// rosa cli quota verification, e.g. "Service ec2 quota code L-0263D0A3 Number of EIPs - VPC EIPs not valid"
+	regexp.MustCompile(`quota code \S+ (.+?) not valid`),
+	// aws api errors, e.g. VpcLimitExceeded, AddressLimitExceeded, InstanceLimitExceeded
+	regexp.MustCompile(`\b(\w+)LimitExceeded\b`),
+	// service quotas errors, e.g. "You have requested more vCPU capacity than your current vCPU limit"
+	regexp.MustCompile(`more (\S+) capacity than your current`),
+}
+
+// QuotaExceededError is returned when the cluster or its vpc cannot be created
+// because an aws service quota is exhausted. It matches ErrQuotaExceeded
+type QuotaExceededError struct {
+	// Resource is the aws resource whose quota is exhausted as reported by aws
+	Resource string
+	err      error
+}
+
+// Error returns the formatted error message when QuotaExceededError is invoked
+func (q *QuotaExceededError) Error() string {
+	return fmt.Sprintf("aws %s quota exceeded: %v", q.Resource, q.err)
+}
+
+// Unwrap returns the error wrapped by QuotaExceededError
+func (q *QuotaExceededError) Unwrap() error {
+	return q.err
+}
+
+// Is reports the error as ErrQuotaExceeded
+func (q *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// quotaError returns a QuotaExceededError wrapping err when the output reports an
+// exhausted aws quota, otherwise err is returned
+func quotaError(output string, err error) error {
+	for _, pattern := range quotaPatterns {
+		if match := pattern.FindStringSubmatch(output); match != nil {
+			return &QuotaExceededError{Resource: strings.TrimSpace(match[1]), err: err}
+		}
+	}
+	return err
+}
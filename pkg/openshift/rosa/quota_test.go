@@ -0,0 +1,60 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("quota errors", func() {
+	DescribeTable("should parse the exhausted resource",
+		func(output, resource string) {
+			err := quotaError(output, errors.New("create failed"))
+
+			var quotaErr *QuotaExceededError
+			Expect(errors.As(err, &quotaErr)).Should(BeTrue())
+			Expect(quotaErr.Resource).Should(Equal(resource))
+			Expect(errors.Is(err, ErrQuotaExceeded)).Should(BeTrue())
+			Expect(err).Should(MatchError(ContainSubstring("create failed")))
+		},
+		Entry("from the rosa quota verification",
+			"E: Insufficient AWS quotas: Service ec2 quota code L-0263D0A3 Number of EIPs - VPC EIPs not valid, expected quota of at least 5, but got 2",
+			"Number of EIPs - VPC EIPs"),
+		Entry("from aws api errors",
+			"Error: creating EC2 VPC: VpcLimitExceeded: The maximum number of VPCs has been reached.",
+			"Vpc"),
+		Entry("from vcpu limits",
+			"You have requested more vCPU capacity than your current vCPU limit of 32 allows",
+			"vCPU"),
+	)
+
+	It("should return other errors unchanged", func() {
+		err := errors.New("create failed")
+		Expect(quotaError("E: Cluster name is already in use", err)).Should(BeIdenticalTo(err))
+	})
+
+	It("should return the quota error when the rosa cli fails on quota", func(ctx context.Context) {
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		script := "#!/bin/sh\necho 'E: Insufficient AWS quotas: Service ec2 quota code L-F678F1CE VPCs per Region not valid' >&2\nexit 1\n"
+		Expect(os.WriteFile(rosaBinary, []byte(script), 0o755)).Should(Succeed())
+
+		provider := &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+
+		_, err := provider.createCluster(ctx, &CreateClusterOptions{ClusterName: "test", Version: "4.15.10", MachineCidr: "10.0.0.0/16"})
+		Expect(errors.Is(&clusterError{action: "create", err: err}, ErrQuotaExceeded)).Should(BeTrue())
+
+		var quotaErr *QuotaExceededError
+		Expect(errors.As(err, &quotaErr)).Should(BeTrue())
+		Expect(quotaErr.Resource).Should(Equal("VPCs per Region"))
+	})
+})
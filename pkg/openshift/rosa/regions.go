@@ -11,6 +11,10 @@ import (
 	"github.com/openshift/osde2e-common/internal/cmd"
 )
 
+// ErrRegionNotEnabled is returned when the aws region is not enabled for the
+// account or does not support the cluster topology
+var ErrRegionNotEnabled = errors.New("region not enabled")
+
 // regionError represents the custom error
 type regionError struct {
 	action string
@@ -22,6 +26,11 @@ func (r *regionError) Error() string {
 	return fmt.Sprintf("region %s failed: %v", r.action, r.err)
 }
 
+// Unwrap returns the error wrapped by regionError
+func (r *regionError) Unwrap() error {
+	return r.err
+}
+
 // region represents a rosa aws region object
 type region struct {
 	ID                 string        `json:"id"`
@@ -61,15 +70,15 @@ func (r *Provider) regionCheck(ctx context.Context, regionName string, hostedCP,
 		regionFound = true
 
 		if !region.Enabled {
-			return &regionError{action: action, err: fmt.Errorf("region %q is not enabled", regionName)}
+			return &regionError{action: action, err: fmt.Errorf("%w: %q", ErrRegionNotEnabled, regionName)}
 		}
 
 		break
 	}
 
 	if !regionFound {
-		return &regionError{action: action, err: fmt.Errorf("region %q is not enabled/valid for the aws account in use and "+
-			"supports: hostedCP=%t, multiAZ=%t", regionName, hostedCP, multiAZ)}
+		return &regionError{action: action, err: fmt.Errorf("%w: region %q is not enabled/valid for the aws account in use and "+
+			"supports: hostedCP=%t, multiAZ=%t", ErrRegionNotEnabled, regionName, hostedCP, multiAZ)}
 	}
 
 	r.log.Info("ROSA AWS region check passed", "region", regionName, "hosted_cp", hostedCP, "multi_az", multiAZ)
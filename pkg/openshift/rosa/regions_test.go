@@ -0,0 +1,50 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("region check", func() {
+	var provider *Provider
+
+	BeforeEach(func() {
+		regions := `[
+  {"id": "us-east-1", "enabled": true},
+  {"id": "us-west-2", "enabled": false}
+]`
+
+		// fake rosa cli listing the regions
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\ncat <<'EOF'\n"+regions+"\nEOF\n"), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+	})
+
+	It("should pass for an enabled region", func(ctx context.Context) {
+		Expect(provider.regionCheck(ctx, "us-east-1", false, false)).Should(Succeed())
+	})
+
+	DescribeTable("should return ErrRegionNotEnabled",
+		func(ctx context.Context, region string) {
+			err := provider.regionCheck(ctx, region, false, false)
+			Expect(errors.Is(err, ErrRegionNotEnabled)).Should(BeTrue())
+
+			var regionErr *regionError
+			Expect(errors.As(&clusterError{action: "create", err: err}, &regionErr)).Should(BeTrue())
+		},
+		Entry("for a disabled region", "us-west-2"),
+		Entry("for an unknown region", "eu-west-1"),
+	)
+})
@@ -0,0 +1,61 @@
+package rosa
+
+import "sync"
+
+// clusterResources represents the resources created alongside a cluster
+type clusterResources struct {
+	// externalOIDC is true when the oidc config and operator roles are managed
+	// outside of the provider and must not be deleted by it
+	externalOIDC bool
+	// network is the vpc created for the cluster
+	network *Network
+}
+
+// createdResources tracks the resources the provider created for each cluster
+// so the cleanup path only deletes what the provider is responsible for
+type createdResources struct {
+	mu        sync.Mutex
+	resources map[string]clusterResources
+}
+
+// newCreatedResources returns an empty created resources tracker
+func newCreatedResources() *createdResources {
+	return &createdResources{resources: map[string]clusterResources{}}
+}
+
+// add records the resources created for the cluster
+func (c *createdResources) add(clusterName string, resources clusterResources) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resources[clusterName] = resources
+}
+
+// get returns the resources recorded for the cluster
+func (c *createdResources) get(clusterName string) (clusterResources, bool) {
+	if c == nil {
+		return clusterResources{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resources, ok := c.resources[clusterName]
+	return resources, ok
+}
+
+// remove forgets the resources recorded for the cluster
+func (c *createdResources) remove(clusterName string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.resources, clusterName)
+}
@@ -2,6 +2,7 @@ package rosa
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -10,18 +11,27 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/openshift/osde2e-common/internal/cmd"
 	ocmclient "github.com/openshift/osde2e-common/pkg/clients/ocm"
 	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+	"github.com/openshift/osde2e-common/pkg/poll"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 )
 
 const (
 	downloadURL = "https://mirror.openshift.com/pub/openshift-v4/clients/rosa"
+
+	// downloadTimeout bounds each attempt to download the rosa cli
+	downloadTimeout = 5 * time.Minute
+	// downloadRetryMax is the number of retries when downloading the rosa cli
+	downloadRetryMax = 3
 )
 
 // Provider is a rosa provider
@@ -33,8 +43,16 @@ type Provider struct {
 
 	AWSRegion  string
 	rosaBinary string
+	// downloadedRosaBinary is true when the provider downloaded the rosa cli
+	downloadedRosaBinary bool
+
+	// PollStrategy controls the interval of the providers wait loops, each
+	// loop uses its own default interval when unset
+	PollStrategy poll.Strategy
 
 	fedRamp bool
+
+	createdResources *createdResources
 }
 
 // providerError represents the provider custom error
@@ -47,99 +65,214 @@ func (r *providerError) Error() string {
 	return fmt.Sprintf("failed to construct rosa provider: %v", r.err)
 }
 
+// Unwrap returns the error wrapped by providerError
+func (r *providerError) Unwrap() error {
+	return r.err
+}
+
 // RunCommand runs the rosa command provided
 func (r *Provider) RunCommand(ctx context.Context, command *exec.Cmd) (io.Writer, io.Writer, error) {
-	command.Env = append(command.Environ(), r.awsCredentials.CredentialsAsList()...)
-	commandWithArgs := fmt.Sprintf("rosa%s", strings.Split(command.String(), "rosa")[1])
+	return r.RunCommandWithCredentials(ctx, command, r.awsCredentials)
+}
+
+// RunCommandWithCredentials runs the rosa command provided using the aws
+// credentials instead of the providers, for commands targeting another account
+func (r *Provider) RunCommandWithCredentials(ctx context.Context, command *exec.Cmd, awsCredentials *awscloud.AWSCredentials) (io.Writer, io.Writer, error) {
+	if awsCredentials == nil {
+		awsCredentials = r.awsCredentials
+	}
+
+	command.Env = append(command.Environ(), awsCredentials.CredentialsAsList()...)
+	commandWithArgs := strings.Join(append([]string{filepath.Base(command.Path)}, command.Args[1:]...), " ")
 	r.log.Info("Command", rosaCommandLoggerKey, commandWithArgs)
-	return cmd.Run(command)
+
+	stdout, stderr, err := cmd.Run(command)
+
+	// rosa writes warnings to stderr, keep them out of the returned stderr so
+	// callers only surface actual errors
+	parsed := cmd.ParseStderr(stderr)
+	for _, warning := range parsed.Warnings {
+		r.log.Info("Command warning", rosaCommandLoggerKey, commandWithArgs, "warning", warning)
+	}
+	if err != nil {
+		for _, line := range parsed.Errors {
+			r.log.Error(errors.New(line), "Command error", rosaCommandLoggerKey, commandWithArgs)
+		}
+	}
+
+	return stdout, bytes.NewBufferString(parsed.String()), err
 }
 
-// Uninstall removes the rosa cli that was downloaded to the systems temp directory
+// runAWSCommand runs the aws cli command using the providers aws credentials and returns its stdout
+func (r *Provider) runAWSCommand(ctx context.Context, args ...string) (string, error) {
+	command := exec.CommandContext(ctx, "aws", args...)
+	command.Env = append(command.Environ(), r.awsCredentials.CredentialsAsList()...)
+
+	r.log.Info("Command", "aws_command", fmt.Sprintf("aws %s", strings.Join(args, " ")))
+
+	stdout, stderr, err := cmd.Run(command)
+	if err != nil {
+		return "", fmt.Errorf("error: %v, stderr: %v", err, stderr)
+	}
+
+	return fmt.Sprint(stdout), nil
+}
+
+// withOperationID returns a copy of the provider whose logger includes a
+// unique id used to correlate all log lines of a single operation
+func (r *Provider) withOperationID() *Provider {
+	provider := *r
+	provider.log = r.log.WithValues(operationIDLoggerKey, utilrand.String(8))
+	return &provider
+}
+
+// waitUntil polls the condition using the providers poll strategy, the interval is used when the strategy has none
+func (r *Provider) waitUntil(ctx context.Context, interval, timeout time.Duration, condition func(ctx context.Context) (bool, error)) error {
+	return poll.Until(ctx, r.PollStrategy.WithDefaultInterval(interval), timeout, condition)
+}
+
+// Uninstall removes the rosa cli when it was downloaded by this process
 func (r *Provider) Uninstall(ctx context.Context) error {
-	if strings.Contains(r.rosaBinary, os.TempDir()) {
-		return os.Remove(r.rosaBinary)
+	if r.downloadedRosaBinary {
+		if err := os.Remove(r.rosaBinary); err != nil {
+			return err
+		}
+		r.downloadedRosaBinary = false
 	}
 	return nil
 }
 
-// cliCheck checks if rosa cli is available else it will download it
-func cliCheck() (string, error) {
-	var (
-		url             = fmt.Sprintf("%s/latest", downloadURL)
-		rosaFilename    = fmt.Sprintf("%s/rosa", os.TempDir())
-		rosaTarFilePath = fmt.Sprintf("%s/rosa.tar.gz", os.TempDir())
-	)
+// Close closes the ocm connection and removes the rosa cli when it was
+// downloaded by this process, it replaces closing the client and calling
+// Uninstall separately
+func (r *Provider) Close(ctx context.Context) error {
+	var errs []error
+
+	if r.Client != nil {
+		if err := r.Client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close ocm connection: %w", err))
+		}
+	}
+
+	if err := r.Uninstall(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to remove rosa cli: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// cliCheck returns the rosa cli set by BinaryEnv, else checks if rosa cli is
+// available else it will use the cached download, downloading it when missing.
+// downloaded is true when this process downloaded the cli
+func cliCheck(ctx context.Context) (rosaBinary string, downloaded bool, err error) {
+	if rosaBinary = os.Getenv(BinaryEnv); rosaBinary != "" {
+		return rosaBinary, false, nil
+	}
+
+	version := os.Getenv(CLIVersionEnv)
+	if version == "" {
+		path, err := exec.LookPath("rosa")
+		if path != "" && err == nil {
+			return path, false, nil
+		}
+		version = latestCLIVersion
+	}
+
+	archive, err := cliArchive(runtime.GOOS)
+	if err != nil {
+		return "", false, err
+	}
+
+	cacheDir := os.Getenv(CacheDirEnv)
+	if cacheDir == "" {
+		cacheDir = os.TempDir()
+	}
 
-	defer func() {
-		_ = os.Remove(rosaTarFilePath)
-	}()
+	return cachedCLI(ctx, newDownloadClient(), fmt.Sprintf("%s/%s/%s", downloadURL, version, archive), cacheDir, version)
+}
 
-	runtimeOS := runtime.GOOS
+// cliArchive returns the name of the rosa cli archive for the operating system
+func cliArchive(runtimeOS string) (string, error) {
 	switch runtimeOS {
 	case "linux":
-		url = fmt.Sprintf("%s/rosa-linux.tar.gz", url)
+		return "rosa-linux.tar.gz", nil
 	case "darwin":
-		url = fmt.Sprintf("%s/rosa-macosx.tar.gz", url)
+		return "rosa-macosx.tar.gz", nil
 	default:
 		return "", fmt.Errorf("operating system %q is not supported", runtimeOS)
 	}
+}
 
-	path, err := exec.LookPath("rosa")
-	if path != "" && err == nil {
-		return path, nil
-	}
-
+// newDownloadClient returns the http client used to download the rosa cli, the
+// timeout and retries bound how long a hung mirror can block the provider
+func newDownloadClient() *retryablehttp.Client {
 	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = downloadRetryMax
+	retryClient.HTTPClient.Timeout = downloadTimeout
 	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		ok, e := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-		if !ok && resp.StatusCode == http.StatusRequestTimeout {
+		if !ok && resp != nil && resp.StatusCode == http.StatusRequestTimeout {
 			return true, nil
 		}
 		return ok, e
 	}
+	return retryClient
+}
 
-	response, err := retryClient.Get(url)
-	if err != nil || response.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("failed to download %s: %v", url, err)
+// downloadCLI downloads the rosa cli tarball and extracts the binary to rosaFilename
+func downloadCLI(client *retryablehttp.Client, url, rosaFilename, rosaTarFilePath string) error {
+	response, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
 	}
 	defer response.Body.Close()
 
-	tarFile, err := os.Create(rosaTarFilePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to create %s tar file: %v", rosaTarFilePath, err)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: unexpected status %q", url, response.Status)
 	}
-	defer tarFile.Close()
 
-	rosaFile, err := os.Create(rosaFilename)
-	if err != nil {
-		return "", fmt.Errorf("failed to create %s tar file: %v", rosaFilename, err)
+	if contentType := response.Header.Get("Content-Type"); strings.HasPrefix(contentType, "text/") {
+		return fmt.Errorf("failed to download %s: unexpected content type %q", url, contentType)
 	}
 
-	err = os.Chmod(rosaFilename, 0o755)
+	tarFile, err := os.Create(rosaTarFilePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to set file permissions to 0755 for %s: %v", rosaFilename, err)
+		return fmt.Errorf("failed to create %s tar file: %v", rosaTarFilePath, err)
 	}
+	defer tarFile.Close()
 
-	defer rosaFile.Close()
-
-	_, err = io.Copy(tarFile, response.Body)
+	size, err := io.Copy(tarFile, response.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to write content to %s: %v", rosaTarFilePath, err)
+		return fmt.Errorf("failed to write content to %s: %v", rosaTarFilePath, err)
+	}
+
+	if size == 0 || (response.ContentLength > 0 && size != response.ContentLength) {
+		return fmt.Errorf("failed to download %s: received %d of %d bytes", url, size, response.ContentLength)
 	}
 
 	tarFileReader, err := os.Open(rosaTarFilePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to open %s: %v", rosaTarFilePath, err)
+		return fmt.Errorf("failed to open %s: %v", rosaTarFilePath, err)
 	}
 	defer tarFileReader.Close()
 
 	gzipReader, err := gzip.NewReader(tarFileReader)
 	if err != nil {
-		return "", fmt.Errorf("failed to create gzip reader for %s: %v", rosaTarFilePath, err)
+		return fmt.Errorf("downloaded %s is not a gzip archive: %v", url, err)
 	}
 	defer gzipReader.Close()
 
+	rosaFile, err := os.Create(rosaFilename)
+	if err != nil {
+		return fmt.Errorf("failed to create %s tar file: %v", rosaFilename, err)
+	}
+	defer rosaFile.Close()
+
+	err = os.Chmod(rosaFilename, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to set file permissions to 0755 for %s: %v", rosaFilename, err)
+	}
+
 	tarReader := tar.NewReader(gzipReader)
 
 	for {
@@ -149,15 +282,15 @@ func cliCheck() (string, error) {
 		}
 
 		if err != nil {
-			break
+			return fmt.Errorf("failed to extract %s: %v", rosaTarFilePath, err)
 		}
 		_, err = io.Copy(rosaFile, tarReader)
 		if err != nil {
-			break
+			return fmt.Errorf("failed to extract %s: %v", rosaTarFilePath, err)
 		}
 	}
 
-	return rosaFilename, nil
+	return nil
 }
 
 // getVersion gets the rosa cli version
@@ -208,27 +341,54 @@ func verifyLogin(ctx context.Context, rosaBinary string, token string, clientID
 
 // New handles constructing the rosa provider which creates a connection
 // to openshift cluster manager "ocm". It is the callers responsibility
-// to close the ocm connection when they are finished (defer provider.Connection.Close())
+// to close the provider when they are finished (defer provider.Close(ctx))
 func New(ctx context.Context, token string, clientID string, clientSecret string, ocmEnvironment ocmclient.Environment, logger logr.Logger, args ...*awscloud.AWSCredentials) (*Provider, error) {
+	opts := []Option{
+		WithToken(token),
+		WithClientCredentials(clientID, clientSecret),
+		WithOCMEnvironment(ocmEnvironment),
+	}
+	if len(args) == 1 {
+		opts = append(opts, WithAWSCredentials(args[0]))
+	}
+
+	return NewWithOptions(ctx, logger, opts...)
+}
+
+// NewWithOptions handles constructing the rosa provider configured by the
+// options, see New
+func NewWithOptions(ctx context.Context, logger logr.Logger, opts ...Option) (*Provider, error) {
+	options := newProviderOptions(opts...)
+	token, clientID, clientSecret, ocmEnvironment := options.token, options.clientID, options.clientSecret, options.ocmEnvironment
+
 	if ocmEnvironment == "" || (token == "" && (clientID == "" || clientSecret == "")) {
 		return nil, &providerError{err: errors.New("some parameters are undefined, unable to construct osd provider")}
 	}
 
-	rosaBinary, err := cliCheck()
-	if err != nil {
+	if err := ocmEnvironment.Validate(); err != nil {
 		return nil, &providerError{err: err}
 	}
+	ocmEnvironment = ocmEnvironment.Normalize()
+
+	rosaBinary, downloadedRosaBinary := options.rosaBinary, false
+	if rosaBinary == "" {
+		var err error
+		rosaBinary, downloadedRosaBinary, err = cliCheck(ctx)
+		if err != nil {
+			return nil, &providerError{err: err}
+		}
+	}
 
 	version, err := getVersion(ctx, rosaBinary)
 	if err != nil {
-		return nil, &providerError{err: err}
+		return nil, &providerError{err: fmt.Errorf("failed to run rosa cli %s: %v", rosaBinary, err)}
 	}
 
 	logger.Info("ROSA version", "version", version)
 
-	awsCredentials := &awscloud.AWSCredentials{}
-	if len(args) == 1 {
-		awsCredentials = args[0]
+	awsCredentials := options.awsCredentials
+	if awsCredentials == nil {
+		awsCredentials = &awscloud.AWSCredentials{}
 	}
 
 	err = awsCredentials.Set()
@@ -249,6 +409,9 @@ func New(ctx context.Context, token string, clientID string, clientSecret string
 		rosaBinary:     rosaBinary,
 		Client:         nil,
 		log:            logger,
+
+		createdResources:     newCreatedResources(),
+		downloadedRosaBinary: downloadedRosaBinary,
 	}
 
 	if awsCredentials.Region == "random" {
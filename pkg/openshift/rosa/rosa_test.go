@@ -0,0 +1,199 @@
+package rosa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/hashicorp/go-retryablehttp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+var _ = Describe("operation id", func() {
+	operationIDPattern := regexp.MustCompile(`"operation_id"="([^"]+)"`)
+
+	It("should be stable within an operation and differ across operations", func() {
+		var lines []string
+		provider := &Provider{log: funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{})}
+
+		first := provider.withOperationID()
+		first.log.Info("first")
+		first.log.Info("second")
+
+		second := provider.withOperationID()
+		second.log.Info("third")
+
+		Expect(lines).Should(HaveLen(3))
+		var ids []string
+		for _, line := range lines {
+			match := operationIDPattern.FindStringSubmatch(line)
+			Expect(match).Should(HaveLen(2), "log line %q is missing the operation id", line)
+			ids = append(ids, match[1])
+		}
+
+		Expect(ids[0]).Should(Equal(ids[1]))
+		Expect(ids[2]).ShouldNot(Equal(ids[0]))
+	})
+})
+
+var _ = Describe("poll strategy", func() {
+	It("should override the default wait interval", func(ctx context.Context) {
+		provider := &Provider{PollStrategy: poll.Strategy{Interval: time.Millisecond}}
+
+		checks := 0
+		start := time.Now()
+		err := provider.waitUntil(ctx, 30*time.Second, time.Minute, func(context.Context) (bool, error) {
+			checks++
+			return checks == 3, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(time.Since(start)).Should(BeNumerically("<", time.Second))
+	})
+
+	It("should use the default wait interval when unset", func(ctx context.Context) {
+		provider := &Provider{}
+
+		err := provider.waitUntil(ctx, time.Second, 50*time.Millisecond, func(context.Context) (bool, error) {
+			Fail("condition checked before the default interval elapsed")
+			return true, nil
+		})
+		Expect(err).Should(MatchError(ContainSubstring("timed out")))
+	})
+})
+
+// rosaTarball returns a gzipped tarball containing a rosa cli with the content
+func rosaTarball(content string) []byte {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	Expect(tarWriter.WriteHeader(&tar.Header{Name: "rosa", Mode: 0o755, Size: int64(len(content))})).Should(Succeed())
+	_, err := tarWriter.Write([]byte(content))
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(tarWriter.Close()).Should(Succeed())
+	Expect(gzipWriter.Close()).Should(Succeed())
+	return buffer.Bytes()
+}
+
+var _ = Describe("cli download", func() {
+	var (
+		client          *retryablehttp.Client
+		rosaFilename    string
+		rosaTarFilePath string
+	)
+
+	serve := func(contentType string, body []byte) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write(body)
+		}))
+		DeferCleanup(server.Close)
+		return server.URL
+	}
+
+	BeforeEach(func() {
+		client = newDownloadClient()
+		client.RetryMax = 0
+		client.Logger = nil
+
+		directory := GinkgoT().TempDir()
+		rosaFilename = filepath.Join(directory, "rosa")
+		rosaTarFilePath = filepath.Join(directory, "rosa.tar.gz")
+	})
+
+	It("should set a timeout and bounded retries", func() {
+		client := newDownloadClient()
+		Expect(client.HTTPClient.Timeout).Should(Equal(downloadTimeout))
+		Expect(client.RetryMax).Should(Equal(downloadRetryMax))
+	})
+
+	It("should extract the rosa binary", func() {
+		url := serve("application/x-gzip", rosaTarball("#!/bin/sh"))
+		Expect(downloadCLI(client, url, rosaFilename, rosaTarFilePath)).Should(Succeed())
+
+		content, err := os.ReadFile(rosaFilename)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).Should(Equal("#!/bin/sh"))
+	})
+
+	It("should reject an html error page", func() {
+		url := serve("text/html", []byte("<html>maintenance</html>"))
+		err := downloadCLI(client, url, rosaFilename, rosaTarFilePath)
+		Expect(err).Should(MatchError(ContainSubstring("unexpected content type")))
+		Expect(rosaFilename).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should reject content that is not gzip", func() {
+		url := serve("application/octet-stream", []byte("<html>maintenance</html>"))
+		err := downloadCLI(client, url, rosaFilename, rosaTarFilePath)
+		Expect(err).Should(MatchError(ContainSubstring("not a gzip archive")))
+		Expect(rosaFilename).ShouldNot(BeAnExistingFile())
+	})
+
+	It("should reject an empty download", func() {
+		url := serve("application/x-gzip", nil)
+		err := downloadCLI(client, url, rosaFilename, rosaTarFilePath)
+		Expect(err).Should(MatchError(ContainSubstring("received 0")))
+	})
+})
+
+var _ = Describe("run command", func() {
+	var provider *Provider
+
+	BeforeEach(func() {
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\necho \"$AWS_ACCESS_KEY_ID $AWS_REGION\"\n"), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			rosaBinary:     rosaBinary,
+			awsCredentials: &awscloud.AWSCredentials{AccessKeyID: "default", SecretAccessKey: "secret", Region: "us-east-1"},
+			log:            logr.Discard(),
+		}
+	})
+
+	It("should use the providers aws credentials", func(ctx context.Context) {
+		stdout, _, err := provider.RunCommand(ctx, exec.CommandContext(ctx, provider.rosaBinary))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fmt.Sprint(stdout)).Should(Equal("default us-east-1\n"))
+	})
+
+	It("should override the providers aws credentials", func(ctx context.Context) {
+		credentials := &awscloud.AWSCredentials{AccessKeyID: "other", SecretAccessKey: "secret", Region: "us-west-2"}
+		stdout, _, err := provider.RunCommandWithCredentials(ctx, exec.CommandContext(ctx, provider.rosaBinary), credentials)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fmt.Sprint(stdout)).Should(Equal("other us-west-2\n"))
+	})
+
+	DescribeTable("should log the command by the binary name",
+		func(binaryDir, binaryName string) {
+			var lines []string
+			provider.log = funcr.New(func(_, args string) { lines = append(lines, args) }, funcr.Options{})
+
+			rosaBinary := filepath.Join(GinkgoT().TempDir(), binaryDir, binaryName)
+			Expect(os.MkdirAll(filepath.Dir(rosaBinary), 0o755)).Should(Succeed())
+			Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\n"), 0o755)).Should(Succeed())
+
+			ctx := context.Background()
+			_, _, err := provider.RunCommand(ctx, exec.CommandContext(ctx, rosaBinary, "list", "clusters"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lines).Should(ContainElement(ContainSubstring(fmt.Sprintf(`"rosa_command"="%s list clusters"`, binaryName))))
+		},
+		Entry("without rosa in the path", "bin", "cli"),
+		Entry("with rosa in the path several times", "rosa-cache", "rosa-1.2.40"),
+	)
+})
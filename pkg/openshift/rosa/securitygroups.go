@@ -0,0 +1,135 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// securityGroup represents the vpc of an aws security group
+type securityGroup struct {
+	ID    string `json:"id"`
+	VPCID string `json:"vpc"`
+}
+
+// securityGroupError represents the custom error
+type securityGroupError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when securityGroupError is invoked
+func (s *securityGroupError) Error() string {
+	return fmt.Sprintf("security group %s failed: %v", s.action, s.err)
+}
+
+// Unwrap returns the error wrapped by securityGroupError
+func (s *securityGroupError) Unwrap() error {
+	return s.err
+}
+
+// additionalSecurityGroupIDs returns the additional security groups of every node type
+func (o *CreateClusterOptions) additionalSecurityGroupIDs() []string {
+	var groupIDs []string
+	groupIDs = append(groupIDs, o.AdditionalComputeSecurityGroupIDs...)
+	groupIDs = append(groupIDs, o.AdditionalInfraSecurityGroupIDs...)
+	groupIDs = append(groupIDs, o.AdditionalControlPlaneSecurityGroupIDs...)
+	return groupIDs
+}
+
+// validateSecurityGroups verifies the additional security groups are only set for
+// clusters installed into existing subnets, it has to run before the provider
+// creates a vpc and sets the subnet ids
+func validateSecurityGroups(options *CreateClusterOptions) error {
+	if len(options.additionalSecurityGroupIDs()) == 0 {
+		return nil
+	}
+
+	var errs []error
+
+	if options.SubnetIDs == "" {
+		errs = append(errs, errors.New("additional security groups require installing into existing subnets (subnet ids)"))
+	}
+
+	if options.HostedCP && (len(options.AdditionalInfraSecurityGroupIDs) > 0 || len(options.AdditionalControlPlaneSecurityGroupIDs) > 0) {
+		errs = append(errs, errors.New("hosted control plane clusters only support additional compute security groups"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// securityGroupsCheck verifies the additional security groups belong to the vpc
+// of the subnets the cluster is installed into
+func (r *Provider) securityGroupsCheck(ctx context.Context, options *CreateClusterOptions) error {
+	const action = "check"
+
+	groupIDs := options.additionalSecurityGroupIDs()
+	if len(groupIDs) == 0 {
+		return nil
+	}
+
+	r.log.Info("Performing security groups check", "security_group_ids", groupIDs, "subnet_ids", options.SubnetIDs)
+
+	stdout, err := r.runAWSCommand(ctx, append([]string{
+		"ec2", "describe-subnets",
+		"--region", r.awsCredentials.Region,
+		"--query", "Subnets[].VpcId",
+		"--output", "json",
+		"--subnet-ids",
+	}, strings.Split(options.SubnetIDs, ",")...)...)
+	if err != nil {
+		return &securityGroupError{action: action, err: fmt.Errorf("failed to describe subnets: %v", err)}
+	}
+
+	var vpcIDs []string
+	if err = json.Unmarshal([]byte(stdout), &vpcIDs); err != nil {
+		return &securityGroupError{action: action, err: fmt.Errorf("failed to parse subnets output: %v", err)}
+	}
+
+	stdout, err = r.runAWSCommand(ctx, append([]string{
+		"ec2", "describe-security-groups",
+		"--region", r.awsCredentials.Region,
+		"--query", "SecurityGroups[].{id:GroupId,vpc:VpcId}",
+		"--output", "json",
+		"--group-ids",
+	}, groupIDs...)...)
+	if err != nil {
+		return &securityGroupError{action: action, err: fmt.Errorf("failed to describe security groups: %v", err)}
+	}
+
+	var securityGroups []securityGroup
+	if err = json.Unmarshal([]byte(stdout), &securityGroups); err != nil {
+		return &securityGroupError{action: action, err: fmt.Errorf("failed to parse security groups output: %v", err)}
+	}
+
+	if err = validateSecurityGroupsVPC(vpcIDs, securityGroups); err != nil {
+		return &securityGroupError{action: action, err: err}
+	}
+
+	r.log.Info("Security groups check passed", "security_group_ids", groupIDs)
+
+	return nil
+}
+
+// validateSecurityGroupsVPC verifies the subnets share a single vpc and each
+// security group belongs to it
+func validateSecurityGroupsVPC(subnetVPCIDs []string, securityGroups []securityGroup) error {
+	vpcIDs := slices.Clone(subnetVPCIDs)
+	slices.Sort(vpcIDs)
+	vpcIDs = slices.Compact(vpcIDs)
+	if len(vpcIDs) != 1 {
+		return fmt.Errorf("subnets must belong to a single vpc, got %v", vpcIDs)
+	}
+
+	var errs []error
+	for _, group := range securityGroups {
+		if group.VPCID != vpcIDs[0] {
+			errs = append(errs, fmt.Errorf("security group %s belongs to vpc %s, not the clusters vpc %s", group.ID, group.VPCID, vpcIDs[0]))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package rosa
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("additional security groups", func() {
+	DescribeTable("should validate the cluster supports them",
+		func(options *CreateClusterOptions, valid bool) {
+			err := validateSecurityGroups(options)
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(HaveOccurred())
+			}
+		},
+		Entry("none", &CreateClusterOptions{}, true),
+		Entry("existing subnets", &CreateClusterOptions{
+			SubnetIDs:                              "subnet-1,subnet-2",
+			AdditionalComputeSecurityGroupIDs:      []string{"sg-1"},
+			AdditionalInfraSecurityGroupIDs:        []string{"sg-2"},
+			AdditionalControlPlaneSecurityGroupIDs: []string{"sg-3"},
+		}, true),
+		Entry("vpc created by the provider", &CreateClusterOptions{
+			AdditionalComputeSecurityGroupIDs: []string{"sg-1"},
+		}, false),
+		Entry("hosted control plane compute", &CreateClusterOptions{
+			HostedCP:                          true,
+			SubnetIDs:                         "subnet-1",
+			AdditionalComputeSecurityGroupIDs: []string{"sg-1"},
+		}, true),
+		Entry("hosted control plane infra", &CreateClusterOptions{
+			HostedCP:                        true,
+			SubnetIDs:                       "subnet-1",
+			AdditionalInfraSecurityGroupIDs: []string{"sg-1"},
+		}, false),
+	)
+
+	DescribeTable("should validate they belong to the subnets vpc",
+		func(subnetVPCIDs []string, securityGroups []securityGroup, valid bool) {
+			err := validateSecurityGroupsVPC(subnetVPCIDs, securityGroups)
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(HaveOccurred())
+			}
+		},
+		Entry("same vpc", []string{"vpc-1", "vpc-1"}, []securityGroup{{ID: "sg-1", VPCID: "vpc-1"}}, true),
+		Entry("other vpc", []string{"vpc-1"}, []securityGroup{{ID: "sg-1", VPCID: "vpc-2"}}, false),
+		Entry("subnets in several vpcs", []string{"vpc-1", "vpc-2"}, []securityGroup{{ID: "sg-1", VPCID: "vpc-1"}}, false),
+	)
+
+	It("should add them to the command args", func() {
+		provider := &Provider{awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"}, log: logr.Discard()}
+		commandArgs := provider.createClusterCommandArgs(&CreateClusterOptions{
+			SubnetIDs:                              "subnet-1",
+			AdditionalComputeSecurityGroupIDs:      []string{"sg-1", "sg-2"},
+			AdditionalControlPlaneSecurityGroupIDs: []string{"sg-3"},
+		})
+		Expect(commandArgs).Should(ContainElements("--additional-compute-security-group-ids", "sg-1,sg-2"))
+		Expect(commandArgs).Should(ContainElements("--additional-control-plane-security-group-ids", "sg-3"))
+		Expect(commandArgs).ShouldNot(ContainElement("--additional-infra-security-group-ids"))
+	})
+
+	It("should be rejected before any resources are created", func(ctx context.Context) {
+		provider := &Provider{awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"}, log: logr.Discard()}
+		_, err := provider.CreateCluster(ctx, &CreateClusterOptions{
+			ClusterName:                       "test",
+			Version:                           "4.15.0",
+			HostedCP:                          true,
+			AdditionalComputeSecurityGroupIDs: []string{"sg-1"},
+			DryRun:                            true,
+		})
+		Expect(err).Should(MatchError(ContainSubstring("existing subnets")))
+	})
+})
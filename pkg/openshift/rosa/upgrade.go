@@ -0,0 +1,199 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	defaultRosaUpgradeTimeout = 3 * time.Hour
+	upgradeScheduleDateFormat = "2006-01-02"
+	upgradeScheduleTimeFormat = "15:04"
+)
+
+// UpgradeOptions represents optional data used when upgrading clusters and machine pools
+type UpgradeOptions struct {
+	// Schedule starts the upgrade at the time instead of immediately
+	Schedule time.Time
+	// Timeout bounds the wait for the upgrade to finish once it starts, defaults to 3 hours
+	Timeout time.Duration
+}
+
+// upgradeError represents the custom error
+type upgradeError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when upgradeError is invoked
+func (u *upgradeError) Error() string {
+	return fmt.Sprintf("%s failed: %v", u.action, u.err)
+}
+
+// Unwrap returns the error wrapped by upgradeError
+func (u *upgradeError) Unwrap() error {
+	return u.err
+}
+
+// timeout returns how long to wait for the upgrade, including the wait for the schedule
+func (o UpgradeOptions) timeout() time.Duration {
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultRosaUpgradeTimeout
+	}
+	if untilSchedule := time.Until(o.Schedule); untilSchedule > 0 {
+		timeout += untilSchedule
+	}
+	return timeout
+}
+
+// scheduleArgs returns the rosa cli arguments scheduling the upgrade
+func (o UpgradeOptions) scheduleArgs() []string {
+	if o.Schedule.IsZero() {
+		return nil
+	}
+	schedule := o.Schedule.UTC()
+	return []string{
+		"--schedule-date", schedule.Format(upgradeScheduleDateFormat),
+		"--schedule-time", schedule.Format(upgradeScheduleTimeFormat),
+	}
+}
+
+// UpgradeCluster upgrades the cluster to the version using the rosa cli and waits
+// for the cluster to report the version. Version gates are acknowledged. Hosted
+// control plane upgrades only upgrade the control plane, machine pools are
+// upgraded separately with UpgradeMachinePool
+func (r *Provider) UpgradeCluster(ctx context.Context, clusterID, version string, opts UpgradeOptions) error {
+	const action = "upgrade cluster"
+
+	r = r.withOperationID()
+
+	if clusterID == "" || version == "" {
+		return &upgradeError{action: action, err: errors.New("cluster id and version are required")}
+	}
+
+	cluster, err := r.findCluster(ctx, clusterID)
+	if err != nil {
+		return &upgradeError{action: action, err: err}
+	}
+
+	commandArgs := upgradeClusterCommandArgs(cluster.ID(), version, cluster.Hypershift().Enabled(), opts)
+
+	r.log.Info("Upgrading cluster", clusterIDLoggerKey, cluster.ID(), versionLoggerKey, version,
+		"schedule", opts.Schedule, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	_, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+	if err != nil {
+		return &upgradeError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+	}
+
+	err = r.waitForVersion(ctx, version, opts.timeout(), func(ctx context.Context) (string, error) {
+		cluster, err := r.findCluster(ctx, cluster.ID())
+		if err != nil {
+			return "", err
+		}
+		return cluster.Version().RawID(), nil
+	})
+	if err != nil {
+		return &upgradeError{action: action, err: err}
+	}
+
+	r.log.Info("Cluster upgraded!", clusterIDLoggerKey, cluster.ID(), versionLoggerKey, version, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return nil
+}
+
+// UpgradeMachinePool upgrades the hosted control plane clusters machine pool to
+// the version using the rosa cli and waits for the machine pool to report the version
+func (r *Provider) UpgradeMachinePool(ctx context.Context, clusterID, machinePoolID, version string, opts UpgradeOptions) error {
+	const action = "upgrade machine pool"
+
+	r = r.withOperationID()
+
+	if clusterID == "" || machinePoolID == "" || version == "" {
+		return &upgradeError{action: action, err: errors.New("cluster id, machine pool id and version are required")}
+	}
+
+	commandArgs := upgradeMachinePoolCommandArgs(clusterID, machinePoolID, version, opts)
+
+	r.log.Info("Upgrading machine pool", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, machinePoolID,
+		versionLoggerKey, version, "schedule", opts.Schedule, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	_, stderr, err := r.RunCommand(ctx, exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+	if err != nil {
+		return &upgradeError{action: action, err: fmt.Errorf("error: %v, stderr: %v", err, stderr)}
+	}
+
+	err = r.waitForVersion(ctx, version, opts.timeout(), func(ctx context.Context) (string, error) {
+		var response *clustersmgmtv1.NodePoolGetResponse
+		err := r.RetryOnAuthError(ctx, func(ctx context.Context) error {
+			var err error
+			response, err = r.ClustersMgmt().V1().Clusters().Cluster(clusterID).NodePools().NodePool(machinePoolID).Get().SendContext(ctx)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return response.Body().Version().RawID(), nil
+	})
+	if err != nil {
+		return &upgradeError{action: action, err: err}
+	}
+
+	r.log.Info("Machine pool upgraded!", clusterIDLoggerKey, clusterID, machinePoolIDLoggerKey, machinePoolID,
+		versionLoggerKey, version, ocmEnvironmentLoggerKey, r.ocmEnvironment)
+
+	return nil
+}
+
+// upgradeClusterCommandArgs returns the rosa cli arguments upgrading the cluster
+func upgradeClusterCommandArgs(clusterID, version string, hostedCP bool, opts UpgradeOptions) []string {
+	commandArgs := []string{
+		"upgrade", "cluster",
+		"--cluster", clusterID,
+		"--version", version,
+		"--mode", "auto",
+		"--yes",
+	}
+
+	if hostedCP {
+		commandArgs = append(commandArgs, "--control-plane")
+	}
+
+	return append(commandArgs, opts.scheduleArgs()...)
+}
+
+// upgradeMachinePoolCommandArgs returns the rosa cli arguments upgrading the machine pool
+func upgradeMachinePoolCommandArgs(clusterID, machinePoolID, version string, opts UpgradeOptions) []string {
+	commandArgs := []string{
+		"upgrade", "machinepool", machinePoolID,
+		"--cluster", clusterID,
+		"--version", version,
+		"--yes",
+	}
+
+	return append(commandArgs, opts.scheduleArgs()...)
+}
+
+// waitForVersion polls the current version until it matches the version or the timeout elapses
+func (r *Provider) waitForVersion(ctx context.Context, version string, timeout time.Duration, currentVersion func(context.Context) (string, error)) error {
+	r.log.Info("Waiting for upgrade to finish", versionLoggerKey, version, timeoutLoggerKey, timeout)
+
+	return r.waitUntil(ctx, time.Minute, timeout, func(ctx context.Context) (bool, error) {
+		current, err := currentVersion(ctx)
+		if err != nil {
+			r.log.Error(err, "failed to get current version", versionLoggerKey, version)
+			return false, nil
+		}
+		if current != version {
+			r.log.Info("Upgrade is in progress", "current_version", current, versionLoggerKey, version)
+			return false, nil
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,71 @@
+package rosa
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/osde2e-common/pkg/poll"
+)
+
+var _ = Describe("upgrade command args", func() {
+	schedule := time.Date(2024, 5, 1, 14, 30, 0, 0, time.UTC)
+
+	It("should upgrade the control plane of hosted control plane clusters", func() {
+		Expect(upgradeClusterCommandArgs("123", "4.15.10", true, UpgradeOptions{})).Should(Equal([]string{
+			"upgrade", "cluster", "--cluster", "123", "--version", "4.15.10", "--mode", "auto", "--yes", "--control-plane",
+		}))
+	})
+
+	It("should schedule the cluster upgrade", func() {
+		Expect(upgradeClusterCommandArgs("123", "4.15.10", false, UpgradeOptions{Schedule: schedule})).Should(Equal([]string{
+			"upgrade", "cluster", "--cluster", "123", "--version", "4.15.10", "--mode", "auto", "--yes",
+			"--schedule-date", "2024-05-01", "--schedule-time", "14:30",
+		}))
+	})
+
+	It("should upgrade the machine pool", func() {
+		Expect(upgradeMachinePoolCommandArgs("123", "workers", "4.15.10", UpgradeOptions{})).Should(Equal([]string{
+			"upgrade", "machinepool", "workers", "--cluster", "123", "--version", "4.15.10", "--yes",
+		}))
+	})
+
+	It("should wait for the schedule on top of the upgrade timeout", func() {
+		options := UpgradeOptions{Schedule: time.Now().Add(time.Hour), Timeout: time.Hour}
+		Expect(options.timeout()).Should(BeNumerically("~", 2*time.Hour, time.Minute))
+		Expect(UpgradeOptions{}.timeout()).Should(Equal(defaultRosaUpgradeTimeout))
+	})
+})
+
+var _ = Describe("wait for version", func() {
+	var provider *Provider
+
+	BeforeEach(func() {
+		provider = &Provider{log: logr.Discard(), PollStrategy: poll.Strategy{Interval: time.Millisecond}}
+	})
+
+	It("should wait until the version is reached", func(ctx context.Context) {
+		versions := []string{"4.15.8", "", "4.15.10"}
+		calls := 0
+		err := provider.waitForVersion(ctx, "4.15.10", time.Second, func(context.Context) (string, error) {
+			version := versions[calls]
+			calls++
+			if version == "" {
+				return "", errors.New("ocm unavailable")
+			}
+			return version, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(Equal(3))
+	})
+
+	It("should time out when the version is not reached", func(ctx context.Context) {
+		err := provider.waitForVersion(ctx, "4.15.10", 10*time.Millisecond, func(context.Context) (string, error) {
+			return "4.15.8", nil
+		})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -22,6 +22,11 @@ func (v *versionError) Error() string {
 	return fmt.Sprintf("%s versions failed: %s", v.action, v.err)
 }
 
+// Unwrap returns the error wrapped by versionError
+func (v *versionError) Unwrap() error {
+	return v.err
+}
+
 // version represents a rosa version object
 type version struct {
 	ID                        string    `json:"id"`
@@ -102,3 +107,124 @@ func (r *Provider) Versions(ctx context.Context, channelGroup string, hostedCP b
 
 	return versions, nil
 }
+
+// LatestVersion returns the highest enabled rosa version for the channel group,
+// limited to the minor version (e.g. 4.15) when provided
+func (r *Provider) LatestVersion(ctx context.Context, channelGroup string, hostedCP bool, minor string) (*version, error) {
+	return r.selectVersion(ctx, channelGroup, hostedCP, minor, func(v *version) bool {
+		return v.Enabled
+	})
+}
+
+// DefaultVersion returns the default rosa version for the channel group,
+// limited to the minor version (e.g. 4.15) when provided
+func (r *Provider) DefaultVersion(ctx context.Context, channelGroup string, hostedCP bool, minor string) (*version, error) {
+	return r.selectVersion(ctx, channelGroup, hostedCP, minor, func(v *version) bool {
+		return v.Enabled && v.Default
+	})
+}
+
+// selectVersion returns the highest version matching the filter and minor version
+func (r *Provider) selectVersion(ctx context.Context, channelGroup string, hostedCP bool, minor string, filter func(*version) bool) (*version, error) {
+	const action = "select"
+
+	var minorVersion *semver.Version
+	if minor != "" {
+		var err error
+		minorVersion, err = semver.NewVersion(minor)
+		if err != nil {
+			return nil, &versionError{action: action, err: fmt.Errorf("failed to parse minor version %q: %v", minor, err)}
+		}
+	}
+
+	versions, err := r.Versions(ctx, channelGroup, hostedCP)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		selected       *version
+		selectedSemver *semver.Version
+	)
+
+	for _, version := range versions {
+		if !filter(version) {
+			continue
+		}
+
+		parsedVersion, err := semver.NewVersion(version.RawID)
+		if err != nil {
+			return nil, &versionError{action: action, err: fmt.Errorf("failed to build version: %w", err)}
+		}
+
+		if minorVersion != nil && (parsedVersion.Major() != minorVersion.Major() || parsedVersion.Minor() != minorVersion.Minor()) {
+			continue
+		}
+
+		if selectedSemver == nil || parsedVersion.GreaterThan(selectedSemver) {
+			selected, selectedSemver = version, parsedVersion
+		}
+	}
+
+	if selected == nil {
+		return nil, &versionError{action: action, err: fmt.Errorf("no version found in channel group %q matching minor version %q", channelGroup, minor)}
+	}
+
+	return selected, nil
+}
+
+// UpgradeStream limits upgrade targets to minor (y-stream) or patch (z-stream) upgrades
+type UpgradeStream string
+
+const (
+	// YStream upgrades move to a later minor version, e.g. 4.14.z to 4.15.z
+	YStream UpgradeStream = "y-stream"
+	// ZStream upgrades move to a later patch version, e.g. 4.15.8 to 4.15.10
+	ZStream UpgradeStream = "z-stream"
+)
+
+// UpgradeTargets returns the versions the current version can be upgraded to,
+// limited to the upgrade stream when provided
+func (r *Provider) UpgradeTargets(ctx context.Context, channelGroup string, hostedCP bool, currentVersion string, stream ...UpgradeStream) ([]string, error) {
+	const action = "upgrade targets"
+
+	current, err := semver.NewVersion(currentVersion)
+	if err != nil {
+		return nil, &versionError{action: action, err: fmt.Errorf("failed to parse current version %q: %v", currentVersion, err)}
+	}
+
+	versions, err := r.Versions(ctx, channelGroup, hostedCP)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if version.RawID != currentVersion {
+			continue
+		}
+
+		if len(stream) == 0 {
+			return version.AvailableUpgrades, nil
+		}
+
+		var targets []string
+		for _, upgrade := range version.AvailableUpgrades {
+			target, err := semver.NewVersion(upgrade)
+			if err != nil {
+				return nil, &versionError{action: action, err: fmt.Errorf("failed to parse upgrade version %q: %v", upgrade, err)}
+			}
+
+			sameMinor := target.Major() == current.Major() && target.Minor() == current.Minor()
+			for _, s := range stream {
+				if (s == ZStream && sameMinor) || (s == YStream && !sameMinor) {
+					targets = append(targets, upgrade)
+					break
+				}
+			}
+		}
+
+		return targets, nil
+	}
+
+	return nil, &versionError{action: action, err: fmt.Errorf("version %q not found in channel group %q", currentVersion, channelGroup)}
+}
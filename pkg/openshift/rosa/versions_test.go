@@ -0,0 +1,78 @@
+package rosa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	awscloud "github.com/openshift/osde2e-common/pkg/clouds/aws"
+)
+
+var _ = Describe("rosa versions", func() {
+	var provider *Provider
+
+	BeforeEach(func() {
+		versions := `[
+  {"id": "openshift-v4.14.20", "raw_id": "4.14.20", "enabled": true, "available_upgrades": ["4.14.21", "4.15.8"]},
+  {"id": "openshift-v4.15.8", "raw_id": "4.15.8", "enabled": true, "default": true, "available_upgrades": ["4.15.9", "4.15.10"]},
+  {"id": "openshift-v4.15.10", "raw_id": "4.15.10", "enabled": true},
+  {"id": "openshift-v4.15.11", "raw_id": "4.15.11", "enabled": false},
+  {"id": "openshift-v4.16.0", "raw_id": "4.16.0", "enabled": true}
+]`
+
+		// fake rosa cli listing the versions
+		rosaBinary := filepath.Join(GinkgoT().TempDir(), "rosa")
+		Expect(os.WriteFile(rosaBinary, []byte("#!/bin/sh\ncat <<'EOF'\n"+versions+"\nEOF\n"), 0o755)).Should(Succeed())
+
+		provider = &Provider{
+			awsCredentials: &awscloud.AWSCredentials{Region: "us-east-1"},
+			log:            logr.Discard(),
+			rosaBinary:     rosaBinary,
+		}
+	})
+
+	DescribeTable("latest version",
+		func(ctx context.Context, minor, expected string) {
+			version, err := provider.LatestVersion(ctx, "stable", false, minor)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(version.RawID).Should(Equal(expected))
+		},
+		Entry("of the channel group", "", "4.16.0"),
+		Entry("of the minor version ignoring disabled versions", "4.15", "4.15.10"),
+	)
+
+	It("should return the default version", func(ctx context.Context) {
+		version, err := provider.DefaultVersion(ctx, "stable", false, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version.RawID).Should(Equal("4.15.8"))
+	})
+
+	It("should fail when no version matches the minor version", func(ctx context.Context) {
+		_, err := provider.DefaultVersion(ctx, "stable", false, "4.14")
+		Expect(err).Should(MatchError(ContainSubstring("no version found")))
+	})
+
+	It("should fail on an invalid minor version", func(ctx context.Context) {
+		_, err := provider.LatestVersion(ctx, "stable", false, "latest")
+		Expect(err).Should(MatchError(ContainSubstring("failed to parse minor version")))
+	})
+
+	DescribeTable("upgrade targets",
+		func(ctx context.Context, stream []UpgradeStream, expected []string) {
+			targets, err := provider.UpgradeTargets(ctx, "stable", false, "4.14.20", stream...)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(targets).Should(Equal(expected))
+		},
+		Entry("of any stream", nil, []string{"4.14.21", "4.15.8"}),
+		Entry("of the y-stream", []UpgradeStream{YStream}, []string{"4.15.8"}),
+		Entry("of the z-stream", []UpgradeStream{ZStream}, []string{"4.14.21"}),
+	)
+
+	It("should fail to get upgrade targets of an unknown version", func(ctx context.Context) {
+		_, err := provider.UpgradeTargets(ctx, "stable", false, "4.13.0")
+		Expect(err).Should(MatchError(ContainSubstring("not found")))
+	})
+})
@@ -2,22 +2,63 @@ package rosa
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/bits"
+	"net"
 	"os"
-	"strings"
+	"path/filepath"
 
 	"github.com/openshift/osde2e-common/internal/terraform"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 )
 
-// vpc represents the details of an aws vpc
-type vpc struct {
-	privateSubnet     string
-	publicSubnet      string
-	nodePrivateSubnet string
+const (
+	// minVPCPrefixLength is the prefix length of the largest vpc cidr aws allows
+	minVPCPrefixLength = 16
+	// maxSubnetPrefixLength is the prefix length of the smallest subnet aws allows
+	maxSubnetPrefixLength = 28
+)
+
+// Network represents the aws vpc created for a cluster
+type Network struct {
+	// Name is the name tag of the vpc
+	Name             string
+	VPCID            string
+	PrivateSubnetIDs []string
+	PublicSubnetIDs  []string
+	// WorkingDir is the terraform working directory holding the vpcs state
+	WorkingDir string
+}
+
+// subnetIDs returns the subnet ids the cluster is installed into, private
+// link clusters only use the private subnets
+func (n *Network) subnetIDs(privateLink bool) []string {
+	subnetIDs := append([]string{}, n.PrivateSubnetIDs...)
+	if !privateLink {
+		subnetIDs = append(subnetIDs, n.PublicSubnetIDs...)
+	}
+	return subnetIDs
+}
+
+// vpcLayout represents the network of the vpc to create
+type vpcLayout struct {
+	cidr              string
+	availabilityZones int
+	publicSubnets     bool
+}
+
+// subnetNewBits returns the bits added to the vpc cidr prefix to split it
+// evenly between the subnets of each availability zone
+func (l vpcLayout) subnetNewBits() int {
+	subnets := l.availabilityZones
+	if l.publicSubnets {
+		subnets *= 2
+	}
+	return bits.Len(uint(subnets - 1))
 }
 
 // vpcError represents the custom error
@@ -31,6 +72,18 @@ func (h *vpcError) Error() string {
 	return fmt.Sprintf("%s vpc failed: %v", h.action, h.err)
 }
 
+// Unwrap returns the error wrapped by vpcError
+func (h *vpcError) Unwrap() error {
+	return h.err
+}
+
+// vpcWorkingDir returns the terraform working directory of the clusters vpc
+// under the working directory, each cluster keeps its own terraform state so
+// destroying one vpc never touches the vpc of another cluster
+func vpcWorkingDir(workingDir, clusterName string) string {
+	return filepath.Join(workingDir, fmt.Sprintf("%s-vpc", clusterName))
+}
+
 // copyFile copies the srcFile provided to the destFile
 func copyFile(srcFile, destFile string) error {
 	srcReader, err := FS.Open(srcFile)
@@ -54,15 +107,26 @@ func copyFile(srcFile, destFile string) error {
 }
 
 // createVPC creates the aws vpc used for provisioning hosted control plane or private link clusters
-func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workingDir string, hostedCP, privateLink bool) (*vpc, error) {
+func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workingDir string, layout vpcLayout, hostedCP, privateLink bool) (*Network, error) {
 	action := "create"
-	var vpc vpc
 	var tfFile string
 
-	if clusterName == "" || awsRegion == "" || workingDir == "" {
+	if clusterName == "" || awsRegion == "" || workingDir == "" || layout.cidr == "" {
 		return nil, &vpcError{action: action, err: errors.New("one or more parameters is empty")}
 	}
 
+	workingDir = vpcWorkingDir(workingDir, clusterName)
+	network := Network{Name: fmt.Sprintf("%s-vpc", clusterName), WorkingDir: workingDir}
+
+	// the vpc is created with the machine cidr so the cluster nodes fit in it
+	if err := validateVPCCIDR(layout.cidr, layout.subnetNewBits()); err != nil {
+		return nil, &vpcError{action: action, err: err}
+	}
+
+	if err := os.MkdirAll(workingDir, os.FileMode(0o755)); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to create terraform working directory: %v", err)}
+	}
+
 	tf, err := terraform.New(ctx, workingDir)
 	if err != nil {
 		return nil, &vpcError{action: action, err: fmt.Errorf("failed to construct terraform runner: %v", err)}
@@ -76,6 +140,35 @@ func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workin
 		_ = tf.Uninstall(ctx)
 	}()
 
+	// the zones are listed from their own working directory so no vpc resources
+	// are planned until the region is known to have enough of them
+	zonesDir := filepath.Join(workingDir, "availability-zones")
+	if err = os.MkdirAll(zonesDir, os.FileMode(0o755)); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to create availability zones working directory: %v", err)}
+	}
+
+	if err = copyFile("assets/availability-zones.tf", filepath.Join(zonesDir, "availability-zones.tf")); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to copy terraform file to availability zones working directory: %v", err)}
+	}
+
+	zonesTF, err := tf.InDir(zonesDir)
+	if err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to construct availability zones terraform runner: %v", err)}
+	}
+
+	if err = zonesTF.SetEnvVars(r.awsCredentials.CredentialsAsMap()); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to set availability zones terraform runner aws credentials (env vars): %v", err)}
+	}
+
+	availabilityZones, err := regionAvailabilityZones(ctx, zonesTF, awsRegion)
+	if err != nil {
+		return nil, &vpcError{action: action, err: err}
+	}
+
+	if err = validateAvailabilityZones(awsRegion, layout.availabilityZones, availabilityZones); err != nil {
+		return nil, &vpcError{action: action, err: err}
+	}
+
 	r.log.Info("Creating aws vpc", clusterNameLoggerKey, clusterName, awsRegionLoggerKey, awsRegion)
 
 	switch {
@@ -100,6 +193,9 @@ func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workin
 		ctx,
 		tfexec.Var(fmt.Sprintf("aws_region=%s", awsRegion)),
 		tfexec.Var(fmt.Sprintf("cluster_name=%s", clusterName)),
+		tfexec.Var(fmt.Sprintf("cidr=%s", layout.cidr)),
+		tfexec.Var(fmt.Sprintf("az_count=%d", layout.availabilityZones)),
+		tfexec.Var(fmt.Sprintf("create_public_subnets=%t", layout.publicSubnets)),
 	)
 	if err != nil {
 		return nil, &vpcError{action: action, err: fmt.Errorf("failed to perform terraform plan: %v", err)}
@@ -107,7 +203,7 @@ func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workin
 
 	err = tf.Apply(ctx)
 	if err != nil {
-		return nil, &vpcError{action: action, err: fmt.Errorf("failed to perform terraform apply: %v", err)}
+		return nil, &vpcError{action: action, err: quotaError(err.Error(), fmt.Errorf("failed to perform terraform apply: %v", err))}
 	}
 
 	output, err := tf.Output(ctx)
@@ -115,13 +211,96 @@ func (r *Provider) createVPC(ctx context.Context, clusterName, awsRegion, workin
 		return nil, &vpcError{action: action, err: fmt.Errorf("failed to perform terraform output: %v", err)}
 	}
 
-	vpc.privateSubnet = strings.ReplaceAll(string(output["cluster-private-subnet"].Value), "\"", "")
-	vpc.publicSubnet = strings.ReplaceAll(string(output["cluster-public-subnet"].Value), "\"", "")
-	vpc.nodePrivateSubnet = strings.ReplaceAll(string(output["node-private-subnet"].Value), "\"", "")
+	if err = json.Unmarshal(output["vpc-id"].Value, &network.VPCID); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to parse vpc id output: %v", err)}
+	}
+
+	if err = json.Unmarshal(output["private-subnets"].Value, &network.PrivateSubnetIDs); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to parse private subnets output: %v", err)}
+	}
+
+	if err = json.Unmarshal(output["public-subnets"].Value, &network.PublicSubnetIDs); err != nil {
+		return nil, &vpcError{action: action, err: fmt.Errorf("failed to parse public subnets output: %v", err)}
+	}
+
+	r.log.Info("AWS vpc created!", clusterNameLoggerKey, clusterName, "vpc_id", network.VPCID, terraformWorkingDirLoggerKey, workingDir)
+
+	return &network, err
+}
+
+// terraformDataRunner is the terraform runner used to apply a config holding only data sources
+type terraformDataRunner interface {
+	Init(ctx context.Context) error
+	Plan(ctx context.Context, args ...tfexec.PlanOption) error
+	Apply(ctx context.Context) error
+	Output(ctx context.Context) (map[string]tfexec.OutputMeta, error)
+}
 
-	r.log.Info("AWS vpc created!", clusterNameLoggerKey, clusterName, terraformWorkingDirLoggerKey, workingDir)
+// regionAvailabilityZones returns the available availability zones of the
+// region from the availability zones terraform config, applying it only reads
+// the aws_availability_zones data source
+func regionAvailabilityZones(ctx context.Context, tf terraformDataRunner, awsRegion string) ([]string, error) {
+	if err := tf.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to perform availability zones terraform init: %v", err)
+	}
+
+	if err := tf.Plan(ctx, tfexec.Var(fmt.Sprintf("aws_region=%s", awsRegion))); err != nil {
+		return nil, fmt.Errorf("failed to perform availability zones terraform plan: %v", err)
+	}
 
-	return &vpc, err
+	if err := tf.Apply(ctx); err != nil {
+		return nil, fmt.Errorf("failed to perform availability zones terraform apply: %v", err)
+	}
+
+	output, err := tf.Output(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform availability zones terraform output: %v", err)
+	}
+
+	var availabilityZones []string
+	if err = json.Unmarshal(output["availability-zones"].Value, &availabilityZones); err != nil {
+		return nil, fmt.Errorf("failed to parse availability zones output: %v", err)
+	}
+
+	return availabilityZones, nil
+}
+
+// validateAvailabilityZones validates the region has the requested number of availability zones
+func validateAvailabilityZones(awsRegion string, requested int, availabilityZones []string) error {
+	if requested < 1 {
+		return fmt.Errorf("at least one availability zone is required, got %d", requested)
+	}
+
+	if len(availabilityZones) < requested {
+		return fmt.Errorf("region %q has %d available availability zones %v, %d are required", awsRegion, len(availabilityZones), availabilityZones, requested)
+	}
+
+	return nil
+}
+
+// validateVPCCIDR validates the cidr can be used as the vpc cidr and split into
+// subnets using the subnet new bits, aws vpcs allow /16 to /28 cidrs and
+// subnets no smaller than /28
+func validateVPCCIDR(cidr string, subnetNewBits int) error {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("machine cidr %q is invalid: %v", cidr, err)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits != net.IPv4len*8 {
+		return fmt.Errorf("machine cidr %q must be an ipv4 cidr", cidr)
+	}
+
+	if ones < minVPCPrefixLength {
+		return fmt.Errorf("machine cidr %q is larger than the largest vpc cidr /%d", cidr, minVPCPrefixLength)
+	}
+
+	if ones+subnetNewBits > maxSubnetPrefixLength {
+		return fmt.Errorf("machine cidr %q is too small to split into /%d subnets, the smallest subnet is /%d", cidr, ones+subnetNewBits, maxSubnetPrefixLength)
+	}
+
+	return nil
 }
 
 // deleteVPC deletes the aws vpc used for provisioning hosted control plane or private link clusters
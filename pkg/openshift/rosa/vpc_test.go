@@ -0,0 +1,146 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeTerraformDataRunner records the terraform commands run and returns the outputs
+type fakeTerraformDataRunner struct {
+	commands []string
+	outputs  map[string]tfexec.OutputMeta
+	applyErr error
+}
+
+func (f *fakeTerraformDataRunner) Init(context.Context) error {
+	f.commands = append(f.commands, "init")
+	return nil
+}
+
+func (f *fakeTerraformDataRunner) Plan(_ context.Context, args ...tfexec.PlanOption) error {
+	f.commands = append(f.commands, "plan")
+	Expect(args).Should(Equal([]tfexec.PlanOption{tfexec.Var("aws_region=us-east-1")}))
+	return nil
+}
+
+func (f *fakeTerraformDataRunner) Apply(context.Context) error {
+	f.commands = append(f.commands, "apply")
+	return f.applyErr
+}
+
+func (f *fakeTerraformDataRunner) Output(context.Context) (map[string]tfexec.OutputMeta, error) {
+	f.commands = append(f.commands, "output")
+	return f.outputs, nil
+}
+
+var _ = Describe("vpc cidr", func() {
+	DescribeTable("should validate the machine cidr fits the vpc",
+		func(cidr string, subnetNewBits int, valid bool) {
+			err := validateVPCCIDR(cidr, subnetNewBits)
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(HaveOccurred())
+			}
+		},
+		Entry("default machine cidr", defaultMachineCIDR, 2, true),
+		Entry("non overlapping machine cidr", "10.1.0.0/20", 2, true),
+		Entry("smallest subnets", "10.0.0.0/26", 2, true),
+		Entry("subnets smaller than aws allows", "10.0.0.0/27", 2, false),
+		Entry("larger than the largest vpc", "10.0.0.0/8", 2, false),
+		Entry("ipv6", "fd00::/48", 2, false),
+		Entry("invalid", "10.0.0.0", 2, false),
+	)
+})
+
+var _ = Describe("vpc layout", func() {
+	DescribeTable("should split the vpc cidr between the subnets",
+		func(layout vpcLayout, expected int) {
+			Expect(layout.subnetNewBits()).Should(Equal(expected))
+		},
+		Entry("single az", vpcLayout{availabilityZones: 1, publicSubnets: true}, 1),
+		Entry("single az private subnets only", vpcLayout{availabilityZones: 1}, 0),
+		Entry("two azs", vpcLayout{availabilityZones: 2, publicSubnets: true}, 2),
+		Entry("three azs", vpcLayout{availabilityZones: 3, publicSubnets: true}, 3),
+		Entry("three azs private subnets only", vpcLayout{availabilityZones: 3}, 2),
+	)
+
+	DescribeTable("should default the availability zones",
+		func(options *CreateClusterOptions, expected vpcLayout) {
+			options.MachineCidr = defaultMachineCIDR
+			expected.cidr = defaultMachineCIDR
+			Expect(options.vpcLayout()).Should(Equal(expected))
+		},
+		Entry("single az", &CreateClusterOptions{}, vpcLayout{availabilityZones: 1, publicSubnets: true}),
+		Entry("multi az", &CreateClusterOptions{MultiAZ: true}, vpcLayout{availabilityZones: 3, publicSubnets: true}),
+		Entry("explicit", &CreateClusterOptions{HostedCP: true, VPCAvailabilityZones: 2}, vpcLayout{availabilityZones: 2, publicSubnets: true}),
+		Entry("private subnets only", &CreateClusterOptions{PrivateLink: true, VPCPrivateSubnetsOnly: true}, vpcLayout{availabilityZones: 1}),
+	)
+
+	DescribeTable("should validate the region has enough availability zones",
+		func(requested int, availabilityZones []string, expectedErr string) {
+			err := validateAvailabilityZones("us-east-1", requested, availabilityZones)
+			if expectedErr == "" {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("as many as requested", 2, []string{"us-east-1a", "us-east-1b"}, ""),
+		Entry("more than requested", 1, []string{"us-east-1a", "us-east-1b"}, ""),
+		Entry("fewer than requested", 3, []string{"us-east-1a", "us-east-1b"},
+			`region "us-east-1" has 2 available availability zones [us-east-1a us-east-1b], 3 are required`),
+		Entry("none available", 1, nil, `region "us-east-1" has 0 available availability zones`),
+		Entry("none requested", 0, []string{"us-east-1a"}, "at least one availability zone is required"),
+	)
+
+	It("should only install private link clusters into private subnets", func() {
+		created := &Network{PrivateSubnetIDs: []string{"subnet-a", "subnet-b"}, PublicSubnetIDs: []string{"subnet-c", "subnet-d"}}
+		Expect(created.subnetIDs(false)).Should(Equal([]string{"subnet-a", "subnet-b", "subnet-c", "subnet-d"}))
+		Expect(created.subnetIDs(true)).Should(Equal([]string{"subnet-a", "subnet-b"}))
+	})
+})
+
+var _ = Describe("region availability zones", func() {
+	It("should only declare data sources", func() {
+		config, err := FS.ReadFile("assets/availability-zones.tf")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(regexp.MustCompile(`(?m)^(resource|module) `).Match(config)).Should(BeFalse())
+		Expect(string(config)).Should(ContainSubstring(`output "availability-zones"`))
+	})
+
+	It("should return the zones output", func(ctx context.Context) {
+		zones, err := json.Marshal([]string{"us-east-1a", "us-east-1b"})
+		Expect(err).ShouldNot(HaveOccurred())
+		tf := &fakeTerraformDataRunner{outputs: map[string]tfexec.OutputMeta{"availability-zones": {Value: zones}}}
+
+		availabilityZones, err := regionAvailabilityZones(ctx, tf, "us-east-1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(availabilityZones).Should(Equal([]string{"us-east-1a", "us-east-1b"}))
+		Expect(tf.commands).Should(Equal([]string{"init", "plan", "apply", "output"}))
+
+		err = validateAvailabilityZones("us-east-1", 3, availabilityZones)
+		Expect(err).Should(MatchError(ContainSubstring(`region "us-east-1" has 2 available availability zones`)))
+	})
+
+	It("should fail without the zones output", func(ctx context.Context) {
+		tf := &fakeTerraformDataRunner{outputs: map[string]tfexec.OutputMeta{}}
+
+		_, err := regionAvailabilityZones(ctx, tf, "us-east-1")
+		Expect(err).Should(MatchError(ContainSubstring("failed to parse availability zones output")))
+	})
+
+	It("should fail when the apply fails", func(ctx context.Context) {
+		tf := &fakeTerraformDataRunner{applyErr: errors.New("no valid credential sources found")}
+
+		_, err := regionAvailabilityZones(ctx, tf, "us-east-1")
+		Expect(err).Should(MatchError(ContainSubstring("no valid credential sources found")))
+		Expect(tf.commands).ShouldNot(ContainElement("output"))
+	})
+})
@@ -0,0 +1,110 @@
+// Package poll provides a configurable strategy for polling conditions
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Strategy controls the interval between condition checks. After each check
+// the interval is multiplied by the backoff factor, up to the max interval.
+// A backoff factor of 1 or less keeps the interval constant
+type Strategy struct {
+	Interval      time.Duration
+	BackoffFactor float64
+	MaxInterval   time.Duration
+}
+
+// WithDefaultInterval returns a copy of the strategy using the interval provided when unset
+func (s Strategy) WithDefaultInterval(interval time.Duration) Strategy {
+	if s.Interval <= 0 {
+		s.Interval = interval
+	}
+	return s
+}
+
+// Backoff returns a function returning the interval to wait before each subsequent check
+func (s Strategy) Backoff() func() time.Duration {
+	interval := s.capped(s.Interval)
+
+	return func() time.Duration {
+		current := interval
+		if s.BackoffFactor > 1 {
+			interval = s.capped(time.Duration(float64(interval) * s.BackoffFactor))
+		}
+		return current
+	}
+}
+
+// capped limits the interval to the max interval when set
+func (s Strategy) capped(interval time.Duration) time.Duration {
+	if s.MaxInterval > 0 && interval > s.MaxInterval {
+		return s.MaxInterval
+	}
+	return interval
+}
+
+// Until checks the condition after each interval of the strategy until it
+// returns true or an error, the timeout elapses or the context is done
+func Until(ctx context.Context, strategy Strategy, timeout time.Duration, condition func(ctx context.Context) (bool, error)) error {
+	if strategy.Interval <= 0 {
+		return errors.New("poll interval must be greater than zero")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	next := strategy.Backoff()
+	timer := time.NewTimer(next())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for the condition", timeout)
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timer.Reset(next())
+	}
+}
+
+// Retry invokes fn until it succeeds or the attempts are exhausted, waiting the
+// strategies interval between attempts. The last error is returned on failure
+func Retry(ctx context.Context, strategy Strategy, attempts int, fn func(ctx context.Context) error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	next := strategy.Backoff()
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if attempt >= attempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempts, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-time.After(next()):
+		}
+	}
+}
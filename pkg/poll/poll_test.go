@@ -0,0 +1,90 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("poll", func() {
+	DescribeTable("should compute the intervals from the strategy",
+		func(strategy Strategy, expected []time.Duration) {
+			next := strategy.Backoff()
+			intervals := make([]time.Duration, 0, len(expected))
+			for range expected {
+				intervals = append(intervals, next())
+			}
+			Expect(intervals).Should(Equal(expected))
+		},
+		Entry("constant", Strategy{Interval: 30 * time.Second},
+			[]time.Duration{30 * time.Second, 30 * time.Second, 30 * time.Second}),
+		Entry("backoff", Strategy{Interval: 10 * time.Second, BackoffFactor: 2},
+			[]time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second}),
+		Entry("backoff capped", Strategy{Interval: 10 * time.Second, BackoffFactor: 2, MaxInterval: 30 * time.Second},
+			[]time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}),
+		Entry("interval above max", Strategy{Interval: time.Minute, MaxInterval: 30 * time.Second},
+			[]time.Duration{30 * time.Second, 30 * time.Second}),
+	)
+
+	It("should only default the interval when unset", func() {
+		Expect(Strategy{}.WithDefaultInterval(30 * time.Second).Interval).Should(Equal(30 * time.Second))
+		Expect(Strategy{Interval: time.Second}.WithDefaultInterval(30 * time.Second).Interval).Should(Equal(time.Second))
+	})
+
+	It("should poll at the strategy intervals until the condition is met", func(ctx context.Context) {
+		var checks []time.Time
+		start := time.Now()
+		err := Until(ctx, Strategy{Interval: 10 * time.Millisecond, BackoffFactor: 2}, time.Second, func(context.Context) (bool, error) {
+			checks = append(checks, time.Now())
+			return len(checks) == 3, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(checks).Should(HaveLen(3))
+		// 10ms + 20ms + 40ms
+		Expect(checks[2].Sub(start)).Should(BeNumerically(">=", 70*time.Millisecond))
+	})
+
+	It("should return the condition error", func(ctx context.Context) {
+		err := Until(ctx, Strategy{Interval: time.Millisecond}, time.Second, func(context.Context) (bool, error) {
+			return false, errors.New("boom")
+		})
+		Expect(err).Should(MatchError("boom"))
+	})
+
+	It("should time out when the condition is never met", func(ctx context.Context) {
+		err := Until(ctx, Strategy{Interval: time.Millisecond}, 20*time.Millisecond, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		Expect(err).Should(MatchError(ContainSubstring("timed out")))
+	})
+
+	It("should require an interval", func(ctx context.Context) {
+		Expect(Until(ctx, Strategy{}, time.Second, func(context.Context) (bool, error) { return true, nil })).ShouldNot(Succeed())
+	})
+
+	It("should retry until the function succeeds", func(ctx context.Context) {
+		attempts := 0
+		err := Retry(ctx, Strategy{Interval: time.Millisecond}, 3, func(context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(attempts).Should(Equal(2))
+	})
+
+	It("should return the last error once the attempts are exhausted", func(ctx context.Context) {
+		attempts := 0
+		err := Retry(ctx, Strategy{Interval: time.Millisecond}, 3, func(context.Context) error {
+			attempts++
+			return errors.New("persistent")
+		})
+		Expect(err).Should(MatchError(ContainSubstring("persistent")))
+		Expect(attempts).Should(Equal(3))
+	})
+})